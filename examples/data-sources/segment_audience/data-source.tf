@@ -0,0 +1,12 @@
+# Check on every plan that an audience is live
+check "audience_live" {
+  data "segment_audience" "example" {
+    space_id = segment_audience.example.space_id
+    id       = segment_audience.example.id
+  }
+
+  assert {
+    condition     = data.segment_audience.example.status == "Live"
+    error_message = "The audience is not live."
+  }
+}
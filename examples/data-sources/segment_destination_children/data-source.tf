@@ -0,0 +1,11 @@
+# Gets the subscriptions, filters and insert function instances of a destination
+data "segment_destination_children" "webhook" {
+  destination_id = "abc123"
+}
+
+# Generates import blocks for the subscriptions of the destination
+import {
+  for_each = { for subscription in data.segment_destination_children.webhook.subscriptions : subscription.id => subscription }
+  to       = segment_destination_subscription.webhook[each.key]
+  id       = "${each.value.destination_id}:${each.value.id}"
+}
@@ -2,3 +2,8 @@
 data "segment_destination_metadata" "my_destination_metadata" {
   id = "abc123"
 }
+
+# Looks up the destination metadata info by slug
+data "segment_destination_metadata" "by_slug" {
+  slug = "google-analytics"
+}
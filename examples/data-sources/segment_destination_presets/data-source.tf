@@ -0,0 +1,21 @@
+# Gets the preset subscriptions of an Actions destination
+data "segment_destination_presets" "webhook" {
+  metadata_id = segment_destination.webhook.metadata.id
+}
+
+# Creates the preset subscriptions of the destination, which are then managed like any other subscription
+resource "segment_destination_subscription" "webhook_presets" {
+  for_each = { for subscription in data.segment_destination_presets.webhook.subscriptions : subscription.name => subscription }
+
+  destination_id = segment_destination.webhook.id
+  name           = each.value.name
+  enabled        = true
+  action_id      = each.value.action_id
+  trigger        = each.value.trigger
+  settings       = each.value.settings
+
+  # Ignores later changes to the presets in the catalog
+  lifecycle {
+    ignore_changes = [trigger, settings]
+  }
+}
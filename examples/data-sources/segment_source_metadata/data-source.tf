@@ -2,3 +2,8 @@
 data "segment_source_metadata" "my_source_metadata" {
   id = "abc123"
 }
+
+# Looks up the source metadata info by slug
+data "segment_source_metadata" "by_slug" {
+  slug = "javascript"
+}
@@ -0,0 +1,9 @@
+# Looks up a space by name
+data "segment_space" "example" {
+  name = "Production"
+}
+
+# Reads a space by id
+data "segment_space" "by_id" {
+  id = "my-space-id"
+}
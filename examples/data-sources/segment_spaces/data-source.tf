@@ -0,0 +1,6 @@
+# Lists the spaces of the workspace
+data "segment_spaces" "all" {}
+
+output "space_ids" {
+  value = [for space in data.segment_spaces.all.spaces : space.id]
+}
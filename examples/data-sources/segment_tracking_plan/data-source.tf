@@ -2,3 +2,29 @@
 data "segment_tracking_plan" "example" {
   id = "abc123"
 }
+
+# Exports the tracking plan for Typewriter and generates TypeScript type definitions
+data "segment_tracking_plan" "with_exports" {
+  id                        = "abc123"
+  export_typewriter_plan    = true
+  generate_type_definitions = ["typescript"]
+}
+
+resource "local_file" "typewriter_plan" {
+  filename = "${path.module}/.typewriter/plan.json"
+  content  = data.segment_tracking_plan.with_exports.typewriter_plan
+}
+
+resource "local_file" "tracking_plan_types" {
+  filename = "${path.module}/src/analytics/tracking-plan.ts"
+  content  = data.segment_tracking_plan.with_exports.type_definitions["typescript"]
+}
+
+# Gets only the order events of the tracking plan
+data "segment_tracking_plan" "order_events" {
+  id = "abc123"
+  rule_filter = {
+    type       = "TRACK"
+    key_prefix = "Order "
+  }
+}
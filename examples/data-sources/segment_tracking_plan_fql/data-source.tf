@@ -0,0 +1,18 @@
+# Derives FQL statements from a tracking plan
+data "segment_tracking_plan_fql" "example" {
+  tracking_plan_id = "abc123"
+}
+
+# Drops anything not in the tracking plan before it reaches the destination
+resource "segment_destination_filter" "drop_unplanned" {
+  if             = data.segment_tracking_plan_fql.example.unplanned
+  destination_id = "abc123"
+  source_id      = "xyz321"
+  title          = "Drop unplanned events"
+  enabled        = true
+  actions = [
+    {
+      type = "DROP"
+    },
+  ]
+}
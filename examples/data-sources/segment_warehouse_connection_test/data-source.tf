@@ -0,0 +1,18 @@
+# Check on every plan that Segment can still connect to a warehouse
+check "warehouse_connection" {
+  data "segment_warehouse_connection_test" "example" {
+    metadata_id = segment_warehouse.example.metadata.id
+    settings = jsonencode({
+      "host" : "example.redshift.amazonaws.com",
+      "port" : "5439",
+      "database" : "segment",
+      "username" : "segment",
+      "password" : var.warehouse_password
+    })
+  }
+
+  assert {
+    condition     = data.segment_warehouse_connection_test.example.valid
+    error_message = "Segment can not connect to the warehouse: ${join(", ", [for error in data.segment_warehouse_connection_test.example.errors : error.message])}"
+  }
+}
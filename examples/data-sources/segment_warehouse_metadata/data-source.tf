@@ -2,3 +2,8 @@
 data "segment_warehouse_metadata" "my_warehouse_metadata" {
   id = "abc123"
 }
+
+# Looks up the warehouse metadata info by slug
+data "segment_warehouse_metadata" "by_slug" {
+  slug = "snowflake"
+}
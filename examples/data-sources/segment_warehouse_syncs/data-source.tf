@@ -0,0 +1,15 @@
+# Check on every plan that a warehouse synced successfully in the last 24 hours
+check "warehouse_freshness" {
+  data "segment_warehouse_syncs" "example" {
+    warehouse_id = segment_warehouse.example.id
+    limit        = 50
+  }
+
+  assert {
+    condition = anytrue([
+      for report in data.segment_warehouse_syncs.example.reports :
+      report.end != null && report.status == "SUCCESS" && timecmp(report.end, timeadd(plantimestamp(), "-24h")) > 0
+    ])
+    error_message = "The warehouse has not synced successfully in the last 24 hours."
+  }
+}
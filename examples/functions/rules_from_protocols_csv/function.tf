@@ -0,0 +1,6 @@
+# Creates a tracking plan from a Protocols CSV export
+resource "segment_tracking_plan" "my_tracking_plan" {
+  name  = "my-tracking-plan"
+  type  = "LIVE"
+  rules = provider::segment::rules_from_protocols_csv(file("tracking-plan.csv"))
+}
@@ -0,0 +1,11 @@
+# Configures an audience of the customers who completed more than 3 orders
+resource "segment_audience" "example" {
+  space_id    = data.segment_space.example.id
+  name        = "High value customers"
+  description = "Customers who completed more than 3 orders"
+  enabled     = true
+  definition = {
+    query = "event('Order Completed').count() > 3"
+  }
+  include_historical_data = true
+}
@@ -0,0 +1,11 @@
+# Configures a computed trait with the total amount of the completed orders of each user
+resource "segment_computed_trait" "example" {
+  space_id    = data.segment_space.example.id
+  name        = "Lifetime value"
+  description = "Total amount of the completed orders"
+  enabled     = true
+  definition = {
+    query = "event('Order Completed').sum(property('total'))"
+  }
+  include_historical_data = true
+}
@@ -11,3 +11,42 @@ resource "segment_destination" "my_destination" {
     "apiKey" : "xyz123"
   })
 }
+
+# Configures a destination whose metadata is looked up in the catalog by slug
+resource "segment_destination" "by_slug" {
+  name      = "My Webhooks Destination"
+  enabled   = true
+  source_id = "s123"
+  metadata = {
+    slug = "webhooks"
+  }
+
+  settings = jsonencode({})
+}
+
+# Configures a destination that only stores the id, name and slug of its catalog metadata in the state
+resource "segment_destination" "without_catalog_metadata" {
+  name      = "My Small State Destination"
+  enabled   = true
+  source_id = "s123"
+  metadata = {
+    id = "dm123"
+  }
+  include_catalog_metadata = false
+
+  settings = jsonencode({
+    "apiKey" : "xyz123"
+  })
+}
+
+# Configures a destination that runs a destination function
+resource "segment_destination" "from_function" {
+  name        = "My Function Destination"
+  enabled     = true
+  source_id   = "s123"
+  function_id = segment_function.destination.id
+
+  settings = jsonencode({
+    "apiKey" : "xyz123"
+  })
+}
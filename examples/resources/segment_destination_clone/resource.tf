@@ -0,0 +1,23 @@
+# Copies a destination, with its subscriptions and filters, to another source
+resource "segment_destination_clone" "webhook_copy" {
+  copy_from_destination_id = segment_destination.webhook.id
+  source_id                = "s456"
+  name                     = "Webhook for s456"
+  enabled                  = true
+
+  # Settings masked by the API are not copied and must be supplied
+  settings = jsonencode({
+    "apiKey" : var.webhook_api_key
+  })
+
+  # Masked settings of subscriptions are supplied by subscription name
+  subscription_settings = {
+    "Send track events" = jsonencode({
+      "apiKey" : var.webhook_api_key
+    })
+  }
+}
+
+output "webhook_copy_masked_settings" {
+  value = segment_destination_clone.webhook_copy.masked_settings
+}
@@ -14,3 +14,24 @@ resource "segment_destination_subscription" "send_to_webhook" {
     "method" : "POST"
   })
 }
+
+# Configures a subscription syncing a Reverse ETL model every weekday at 6 AM
+resource "segment_destination_subscription" "reverse_etl" {
+  destination_id = segment_destination.webhook.id
+  name           = "Webhook Reverse ETL subscription"
+  enabled        = true
+  action_id      = "abc123"
+  trigger        = "event = \"new\""
+  model_id       = segment_reverse_etl_model.example.id
+  settings = jsonencode({
+    "url" : "https://webhook.site/abc-123",
+    "method" : "POST"
+  })
+  reverse_etl_schedule = {
+    strategy = "CRON"
+    cron = {
+      spec     = "0 6 * * 1-5"
+      timezone = "America/New_York"
+    }
+  }
+}
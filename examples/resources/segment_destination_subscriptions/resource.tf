@@ -0,0 +1,26 @@
+# Configures every subscription of a destination, removing the ones that are not in the configuration
+resource "segment_destination_subscriptions" "webhook" {
+  destination_id = segment_destination.webhook.id
+  subscriptions = [
+    {
+      name      = "Webhook send subscription"
+      enabled   = true
+      action_id = "abc123"
+      trigger   = "type = \"track\""
+      settings = jsonencode({
+        "url" : "https://webhook.site/abc-123",
+        "method" : "POST"
+      })
+    },
+    {
+      name      = "Webhook identify subscription"
+      enabled   = false
+      action_id = "abc123"
+      trigger   = "type = \"identify\""
+      settings = jsonencode({
+        "url" : "https://webhook.site/abc-123",
+        "method" : "POST"
+      })
+    },
+  ]
+}
@@ -1,6 +1,6 @@
 # Configures a specific profiles sync warehouse
 resource "segment_profiles_warehouse" "example" {
-  space_id    = "cba321"
+  space_id    = data.segment_space.example.id
   metadata_id = "abc123"
   enabled     = true
   settings = jsonencode({
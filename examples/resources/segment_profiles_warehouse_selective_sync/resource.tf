@@ -0,0 +1,16 @@
+# Configures which Profiles Sync tables and properties are synced to a Profiles Sync warehouse
+resource "segment_profiles_warehouse_selective_sync" "example" {
+  space_id            = data.segment_space.example.id
+  warehouse_id        = segment_profiles_warehouse.example.id
+  enable_event_tables = true
+  collections = [
+    {
+      name    = "page"
+      enabled = false
+    },
+    {
+      name                = "identifies"
+      disabled_properties = ["context_ip"]
+    },
+  ]
+}
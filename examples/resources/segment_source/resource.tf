@@ -16,3 +16,27 @@ resource "segment_source" "my_source" {
     },
   ]
 }
+
+# Configures a source that is automatically connected to all warehouses of the workspace
+resource "segment_source" "auto_connected" {
+  slug    = "my_auto_connected_source_slug"
+  name    = "My Auto Connected Source"
+  enabled = true
+  metadata = {
+    # The metadata can be looked up in the catalog by slug or name instead of id
+    slug = "javascript"
+  }
+  settings                  = jsonencode({})
+  disconnect_all_warehouses = false
+}
+
+# Configures a source that runs a source function
+resource "segment_source" "from_function" {
+  slug        = "my_function_source_slug"
+  name        = "My Function Source"
+  enabled     = true
+  function_id = segment_function.example.id
+  settings = jsonencode({
+    "apiKey" : "xyz123",
+  })
+}
@@ -0,0 +1,19 @@
+# Configures the schema settings of a source that is not connected to a tracking plan
+resource "segment_source_schema_settings" "example" {
+  source_id = segment_source.my_source.id
+  schema_settings = {
+    forwarding_blocked_events_to = segment_source.my_blocked_events_source.id
+    track = {
+      allow_unplanned_events           = true
+      allow_unplanned_event_properties = true
+      allow_event_on_violations        = true
+      allow_properties_on_violations   = true
+      common_event_on_violations       = "ALLOW"
+    }
+    identify = {
+      allow_unplanned_traits     = true
+      allow_traits_on_violations = true
+      common_event_on_violations = "ALLOW"
+    }
+  }
+}
@@ -2,6 +2,8 @@
 resource "segment_source_tracking_plan_connection" "example" {
   source_id        = "abc123"
   tracking_plan_id = "xyz321"
+  # Restore the schema settings the source had before it was connected
+  schema_settings_on_destroy = "RESTORE"
   schema_settings = {
     forwarding_blocked_events_to = segment_source.my_source.id
     track = {
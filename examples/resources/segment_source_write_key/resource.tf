@@ -0,0 +1,21 @@
+# Configures an additional write key for a source
+resource "segment_source_write_key" "example" {
+  source_id = segment_source.my_source.id
+}
+
+# Rotates the write key whenever the rotation date changes
+resource "segment_source_write_key" "rotated" {
+  source_id = segment_source.my_source.id
+  keepers = {
+    rotation = "2025-01-01"
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+output "write_key" {
+  value     = segment_source_write_key.rotated.write_key
+  sensitive = true
+}
@@ -9,3 +9,28 @@ resource "segment_warehouse" "example" {
   })
   name = "My Terraform Warehouse!"
 }
+
+# Configures a warehouse whose metadata is looked up in the catalog by name
+resource "segment_warehouse" "by_name" {
+  metadata = {
+    name = "Snowflake"
+  }
+  enabled = true
+  settings = jsonencode({
+    token : "zyx321"
+  })
+  name = "My Snowflake Warehouse"
+}
+
+# Configures a warehouse without checking that Segment can connect to it, for example while it is being provisioned
+resource "segment_warehouse" "unvalidated" {
+  metadata = {
+    slug = "postgres"
+  }
+  enabled = false
+  settings = jsonencode({
+    host : "postgres.example.com"
+  })
+  name                = "My Postgres Warehouse"
+  validate_connection = false
+}
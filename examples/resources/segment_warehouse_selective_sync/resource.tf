@@ -0,0 +1,15 @@
+# Configures which collections and properties of a source are synced to a warehouse
+resource "segment_warehouse_selective_sync" "example" {
+  warehouse_id = segment_warehouse.example.id
+  source_id    = segment_source.example.id
+  collections = [
+    {
+      name    = "page_viewed"
+      enabled = false
+    },
+    {
+      name                = "identifies"
+      disabled_properties = ["context_ip", "context_user_agent"]
+    },
+  ]
+}
@@ -0,0 +1,7 @@
+# Syncs a warehouse only twice a day, outside of business hours
+resource "segment_warehouse_sync_schedule" "example" {
+  warehouse_id = segment_warehouse.example.id
+  enabled      = true
+  timezone     = "America/New_York"
+  hours        = [2, 20]
+}
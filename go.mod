@@ -10,6 +10,7 @@ require (
 	github.com/hashicorp/terraform-plugin-framework-validators v0.18.0
 	github.com/hashicorp/terraform-plugin-go v0.29.0
 	github.com/hashicorp/terraform-plugin-testing v1.13.3
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/segmentio/public-api-sdk-go v0.0.0-20250113195817-34106b6e08dd
 	github.com/stretchr/testify v1.10.0
 	gotest.tools/gotestsum v1.13.0
@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+
+	"github.com/segmentio/public-api-sdk-go/api"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
+)
+
+var (
+	_ datasource.DataSource              = &audienceDataSource{}
+	_ datasource.DataSourceWithConfigure = &audienceDataSource{}
+)
+
+func NewAudienceDataSource() datasource.DataSource {
+	return &audienceDataSource{}
+}
+
+type audienceDataSource struct {
+	client      *api.APIClient
+	authContext context.Context
+}
+
+func (d *audienceDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_audience"
+}
+
+func (d *audienceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads an Engage Audience, such as to check whether it is live. The size of the Audience is not returned, since the Public API does not expose it. For more information, visit the [Segment docs](https://segment.com/docs/engage/audiences/).",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Required:    true,
+				Description: "The unique identifier of the Audience.",
+			},
+			"space_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The id of the Space.",
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the Audience.",
+			},
+			"description": schema.StringAttribute{
+				Computed:    true,
+				Description: "The description of the Audience.",
+			},
+			"key": schema.StringAttribute{
+				Computed:    true,
+				Description: "The key of the Audience, used as the trait or event name in the Destinations it is sent to.",
+			},
+			"enabled": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the Audience is computed.",
+			},
+			"definition": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "The query that defines the members of the Audience.",
+				Attributes: map[string]schema.Attribute{
+					"query": schema.StringAttribute{
+						Computed:    true,
+						Description: "The query language string that defines the Audience.",
+					},
+					"type": schema.StringAttribute{
+						Computed:    true,
+						Description: "The kind of profiles that are segmented, either `users` or `accounts`.",
+					},
+				},
+			},
+			"include_historical_data": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the data collected before the Audience was created is used to compute its members.",
+			},
+			"include_anonymous_users": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether anonymous profiles can be members of the Audience.",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The status of the Audience, such as `Backfilling`, `Computing`, `Failed`, `Live`, `Awaiting Destinations` or `Disabled`.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time the Audience was created.",
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time the Audience was last updated.",
+			},
+		},
+	}
+}
+
+func (d *audienceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config models.AudienceDataSourceState
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	out, body, err := d.client.AudiencesAPI.GetAudience(d.authContext, config.SpaceID.ValueString(), config.ID.ValueString()).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Audience (ID: %s)", config.ID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+
+	var state models.AudienceDataSourceState
+	state.Fill(out.Data.Audience)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (d *audienceDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected ClientInfo, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = config.client
+	d.authContext = config.authContext
+}
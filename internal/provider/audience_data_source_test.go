@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAudienceDataSource(t *testing.T) {
+	t.Parallel()
+
+	fakeServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("content-type", "application/json")
+
+			if req.URL.Path == "/spaces/my-space-id/audiences/my-audience-id" {
+				_, _ = w.Write([]byte(`
+					{
+						"data": {
+							"audience": {
+								"id": "my-audience-id",
+								"spaceId": "my-space-id",
+								"name": "High value customers",
+								"description": "Customers with more than 3 orders",
+								"key": "high_value_customers",
+								"enabled": true,
+								"definition": {
+									"query": "event('Order Completed').count() > 3",
+									"type": "users"
+								},
+								"status": "Computing",
+								"createdBy": "my-user-id",
+								"updatedBy": "my-user-id",
+								"createdAt": "2024-01-01T00:00:00.000Z",
+								"updatedAt": "2024-01-02T00:00:00.000Z",
+								"options": {
+									"includeHistoricalData": true,
+									"includeAnonymousUsers": false
+								}
+							}
+						}
+					}
+				`))
+			}
+		}),
+	)
+	defer fakeServer.Close()
+
+	providerConfig := `
+		provider "segment" {
+			url   = "` + fakeServer.URL + `"
+			token = "abc123"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+					data "segment_audience" "test" {
+						space_id = "my-space-id"
+						id       = "my-audience-id"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.segment_audience.test", "id", "my-audience-id"),
+					resource.TestCheckResourceAttr("data.segment_audience.test", "space_id", "my-space-id"),
+					resource.TestCheckResourceAttr("data.segment_audience.test", "name", "High value customers"),
+					resource.TestCheckResourceAttr("data.segment_audience.test", "description", "Customers with more than 3 orders"),
+					resource.TestCheckResourceAttr("data.segment_audience.test", "key", "high_value_customers"),
+					resource.TestCheckResourceAttr("data.segment_audience.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("data.segment_audience.test", "definition.query", "event('Order Completed').count() > 3"),
+					resource.TestCheckResourceAttr("data.segment_audience.test", "definition.type", "users"),
+					resource.TestCheckResourceAttr("data.segment_audience.test", "include_historical_data", "true"),
+					resource.TestCheckResourceAttr("data.segment_audience.test", "include_anonymous_users", "false"),
+					resource.TestCheckResourceAttr("data.segment_audience.test", "status", "Computing"),
+					resource.TestCheckResourceAttr("data.segment_audience.test", "created_at", "2024-01-01T00:00:00.000Z"),
+					resource.TestCheckResourceAttr("data.segment_audience.test", "updated_at", "2024-01-02T00:00:00.000Z"),
+				),
+			},
+		},
+	})
+}
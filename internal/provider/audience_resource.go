@@ -0,0 +1,330 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/segmentio/public-api-sdk-go/api"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/docs"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
+)
+
+const (
+	ComputationTypeUsers    = "users"
+	ComputationTypeAccounts = "accounts"
+)
+
+var (
+	_ resource.Resource                = &audienceResource{}
+	_ resource.ResourceWithConfigure   = &audienceResource{}
+	_ resource.ResourceWithImportState = &audienceResource{}
+)
+
+func NewAudienceResource() resource.Resource {
+	return &audienceResource{}
+}
+
+type audienceResource struct {
+	client      *api.APIClient
+	authContext context.Context
+}
+
+func (r *audienceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_audience"
+}
+
+func (r *audienceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Configures an Engage Audience in a Space. For more information, visit the [Segment docs](https://segment.com/docs/engage/audiences/).\n\n" +
+			docs.GenerateImportDocs("<space_id>:<audience_id>", "segment_audience"),
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier of the Audience.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The id of the Space, such as `data.segment_space.example.id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the Audience.",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "The description of the Audience.",
+			},
+			"key": schema.StringAttribute{
+				Computed:    true,
+				Description: "The key of the Audience, used as the trait or event name in the Destinations it is sent to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Required:    true,
+				Description: "Whether the Audience is computed.",
+			},
+			"definition": computationDefinitionSchema("Audience", "The query that defines the members of the Audience.", "`event('Order Completed').count() >= 1`"),
+			"include_historical_data": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether the data collected before the Audience was created is used to compute its members. Segment can include historical data when the definition requires it, in which case the configured value is kept. Changing this forces a new Audience to be created.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"include_anonymous_users": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether anonymous profiles can be members of the Audience. Changing this forces a new Audience to be created.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *audienceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan models.AudienceState
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	out, body, err := r.client.AudiencesAPI.CreateAudience(r.authContext, plan.SpaceID.ValueString()).CreateAudienceAlphaInput(api.CreateAudienceAlphaInput{
+		Name:        plan.Name.ValueString(),
+		Enabled:     plan.Enabled.ValueBoolPointer(),
+		Description: plan.Description.ValueStringPointer(),
+		Definition:  plan.Definition.ToAudienceAPIValue(),
+		Options: &api.AudienceOptions{
+			IncludeHistoricalData: plan.IncludeHistoricalData.ValueBoolPointer(),
+			IncludeAnonymousUsers: plan.IncludeAnonymousUsers.ValueBoolPointer(),
+		},
+	}).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to create Audience in Space (ID: %s)", plan.SpaceID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+
+	state := plan
+	state.Fill(out.Data.Audience)
+	state.IncludeHistoricalData = configuredIncludeHistoricalData(state.IncludeHistoricalData, plan.IncludeHistoricalData)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *audienceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var previousState models.AudienceState
+	diags := req.State.Get(ctx, &previousState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	out, body, err := r.client.AudiencesAPI.GetAudience(r.authContext, previousState.SpaceID.ValueString(), previousState.ID.ValueString()).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		if body != nil && body.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Audience (ID: %s)", previousState.ID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+
+	state := previousState
+	state.Fill(out.Data.Audience)
+	state.IncludeHistoricalData = configuredIncludeHistoricalData(state.IncludeHistoricalData, previousState.IncludeHistoricalData)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *audienceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan models.AudienceState
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state models.AudienceState
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	definition := plan.Definition.ToAudienceAPIValue()
+	input := api.UpdateAudienceForSpaceInput{
+		Name:        plan.Name.ValueStringPointer(),
+		Enabled:     plan.Enabled.ValueBoolPointer(),
+		Description: plan.Description.ValueStringPointer(),
+		Definition:  &definition,
+	}
+	// An omitted description is left untouched, so it is cleared explicitly
+	if plan.Description.IsNull() && !state.Description.IsNull() {
+		input.Description = api.PtrString("")
+	}
+
+	out, body, err := r.client.AudiencesAPI.UpdateAudienceForSpace(r.authContext, state.SpaceID.ValueString(), state.ID.ValueString()).UpdateAudienceForSpaceInput(input).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to update Audience (ID: %s)", state.ID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+
+	previousIncludeHistoricalData := state.IncludeHistoricalData
+	state.Fill(out.Data.Audience)
+	state.IncludeHistoricalData = configuredIncludeHistoricalData(state.IncludeHistoricalData, previousIncludeHistoricalData)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *audienceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var config models.AudienceState
+	diags := req.State.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, body, err := r.client.AudiencesAPI.RemoveAudienceFromSpace(r.authContext, config.SpaceID.ValueString(), config.ID.ValueString()).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to delete Audience (ID: %s)", config.ID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+}
+
+func (r *audienceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, ":")
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <space_id>:<audience_id>. Got: %q", req.ID),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("space_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
+}
+
+func (r *audienceResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected ClientInfo, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = config.client
+	r.authContext = config.authContext
+}
+
+// computationDefinitionSchema returns the schema of the query that defines an Audience or a Computed Trait.
+func computationDefinitionSchema(kind string, description string, example string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Required:    true,
+		Description: description,
+		Attributes: map[string]schema.Attribute{
+			"query": schema.StringAttribute{
+				Required:    true,
+				Description: fmt.Sprintf("The query language string that defines the %s, such as %s. For more information, visit the [Segment docs](https://segment.com/docs/api/public-api/query-language/).", kind, example),
+			},
+			"type": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(ComputationTypeUsers),
+				Description: fmt.Sprintf("The kind of profiles that are computed, either `%s` or `%s`. Defaults to `%s`.", ComputationTypeUsers, ComputationTypeAccounts, ComputationTypeUsers),
+				Validators: []validator.String{
+					stringvalidator.OneOf(ComputationTypeUsers, ComputationTypeAccounts),
+				},
+			},
+		},
+	}
+}
+
+// configuredIncludeHistoricalData keeps a configured false, since Segment includes historical data on its own when the
+// definition requires it.
+func configuredIncludeHistoricalData(remote types.Bool, configured types.Bool) types.Bool {
+	if !configured.IsNull() && !configured.IsUnknown() && !configured.ValueBool() {
+		return configured
+	}
+
+	return remote
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/segmentio/public-api-sdk-go/api"
+)
+
+func TestAccAudienceResource(t *testing.T) {
+	t.Parallel()
+
+	audience := map[string]interface{}{}
+
+	fakeServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("content-type", "application/json")
+
+			var payload map[string]interface{}
+			if req.URL.Path == "/spaces/my-space-id/audiences" && req.Method == http.MethodPost {
+				var input api.CreateAudienceAlphaInput
+				_ = json.NewDecoder(req.Body).Decode(&input)
+
+				audience = map[string]interface{}{
+					"id":          "my-audience-id",
+					"spaceId":     "my-space-id",
+					"name":        input.Name,
+					"description": input.Description,
+					"key":         "high_value_customers",
+					"enabled":     *input.Enabled,
+					"definition":  map[string]interface{}{"query": input.Definition.Query, "type": input.Definition.Type},
+					"status":      "Computing",
+					"createdBy":   "my-user-id",
+					"updatedBy":   "my-user-id",
+					"createdAt":   "2024-01-01T00:00:00.000Z",
+					"updatedAt":   "2024-01-01T00:00:00.000Z",
+					// Historical data is included by Segment even though it was not requested
+					"options": map[string]interface{}{"includeHistoricalData": true, "includeAnonymousUsers": *input.Options.IncludeAnonymousUsers},
+				}
+				payload = map[string]interface{}{"audience": audience}
+			} else if req.URL.Path == "/spaces/my-space-id/audiences/my-audience-id" && req.Method == http.MethodPatch {
+				var input api.UpdateAudienceForSpaceInput
+				_ = json.NewDecoder(req.Body).Decode(&input)
+
+				audience["name"] = *input.Name
+				audience["enabled"] = *input.Enabled
+				audience["description"] = input.Description
+				audience["definition"] = map[string]interface{}{"query": input.Definition.Query, "type": input.Definition.Type}
+				// The options are omitted by the API once the Audience is updated, which keeps the previous values
+				delete(audience, "options")
+				payload = map[string]interface{}{"audience": audience}
+			} else if req.URL.Path == "/spaces/my-space-id/audiences/my-audience-id" && req.Method == http.MethodDelete {
+				payload = map[string]interface{}{"status": "SUCCESS"}
+			} else if req.URL.Path == "/spaces/my-space-id/audiences/my-audience-id" {
+				payload = map[string]interface{}{"audience": audience}
+			}
+
+			out, _ := json.Marshal(map[string]interface{}{"data": payload})
+			_, _ = w.Write(out)
+		}),
+	)
+	defer fakeServer.Close()
+
+	providerConfig := `
+		provider "segment" {
+			url   = "` + fakeServer.URL + `"
+			token = "abc123"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+					resource "segment_audience" "test" {
+						space_id    = "my-space-id"
+						name        = "High value customers"
+						description = "Customers with more than 3 orders"
+						enabled     = true
+						definition = {
+							query = "event('Order Completed').count() > 3"
+						}
+						include_anonymous_users = true
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_audience.test", "id", "my-audience-id"),
+					resource.TestCheckResourceAttr("segment_audience.test", "key", "high_value_customers"),
+					resource.TestCheckResourceAttr("segment_audience.test", "definition.type", "users"),
+					resource.TestCheckResourceAttr("segment_audience.test", "include_historical_data", "false"),
+					resource.TestCheckResourceAttr("segment_audience.test", "include_anonymous_users", "true"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "segment_audience.test",
+				ImportState:             true,
+				ImportStateId:           "my-space-id:my-audience-id",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"include_historical_data"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+					resource "segment_audience" "test" {
+						space_id = "my-space-id"
+						name     = "Loyal customers"
+						enabled  = false
+						definition = {
+							query = "event('Order Completed').count() > 10"
+						}
+						include_anonymous_users = true
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_audience.test", "name", "Loyal customers"),
+					resource.TestCheckResourceAttr("segment_audience.test", "enabled", "false"),
+					resource.TestCheckNoResourceAttr("segment_audience.test", "description"),
+					resource.TestCheckResourceAttr("segment_audience.test", "definition.query", "event('Order Completed').count() > 10"),
+					resource.TestCheckResourceAttr("segment_audience.test", "include_historical_data", "false"),
+					resource.TestCheckResourceAttr("segment_audience.test", "include_anonymous_users", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/segmentio/public-api-sdk-go/api"
+)
+
+// catalogEntry is the part of a Segment catalog item used to look it up.
+type catalogEntry struct {
+	ID   string
+	Slug string
+	Name string
+}
+
+// catalogPage lists a single page of a Segment catalog and returns the cursor of the next one, if any.
+type catalogPage func(pagination api.PaginationInput) ([]catalogEntry, *string, error)
+
+// catalogCache keeps the Segment catalogs listed by a provider instance, since they are needed by the plan of every
+// resource configured with a catalog slug or name and rarely change.
+type catalogCache struct {
+	mutex    sync.Mutex
+	catalogs map[string][]catalogEntry
+}
+
+// list returns every item of the catalog of the kind, listing it only the first time.
+func (c *catalogCache) list(kind string, listPage catalogPage) ([]catalogEntry, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if entries, ok := c.catalogs[kind]; ok {
+		return entries, nil
+	}
+
+	paginationInput := *api.NewPaginationInput(MaxPageSize)
+	entries := []catalogEntry{}
+
+	for {
+		page, next, err := listPage(paginationInput)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, page...)
+
+		if next == nil {
+			break
+		}
+		paginationInput.SetCursor(*next)
+	}
+
+	if c.catalogs == nil {
+		c.catalogs = map[string][]catalogEntry{}
+	}
+	c.catalogs[kind] = entries
+
+	return entries, nil
+}
+
+// findCatalogID returns the id of the catalog item matching the slug, or the name when the slug is empty.
+func findCatalogID(kind string, slug string, name string, entries []catalogEntry) (string, error) {
+	matches := []catalogEntry{}
+	for _, entry := range entries {
+		if slug != "" && entry.Slug == slug {
+			return entry.ID, nil
+		}
+		if slug == "" && entry.Name == name {
+			matches = append(matches, entry)
+		}
+	}
+
+	if slug != "" {
+		return "", fmt.Errorf("no %s with slug %q was found in the Segment catalog", kind, slug)
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no %s with name %q was found in the Segment catalog", kind, name)
+	case 1:
+		return matches[0].ID, nil
+	default:
+		slugs := make([]string, 0, len(matches))
+		for _, match := range matches {
+			slugs = append(slugs, match.Slug)
+		}
+
+		return "", fmt.Errorf("more than one %s with name %q was found in the Segment catalog, use one of the following slugs instead: %s", kind, name, strings.Join(slugs, ", "))
+	}
+}
+
+func findSourceMetadataID(authContext context.Context, client *api.APIClient, catalog *catalogCache, slug string, name string) (string, error) {
+	entries, err := catalog.list("Source", func(pagination api.PaginationInput) ([]catalogEntry, *string, error) {
+		out, body, err := client.CatalogAPI.GetSourcesCatalog(authContext).Pagination(pagination).Execute()
+		if body != nil {
+			defer body.Body.Close()
+		}
+		if err != nil {
+			return nil, nil, errors.New(getError(err, body))
+		}
+
+		entries := []catalogEntry{}
+		for _, metadata := range out.Data.GetSourcesCatalog() {
+			entries = append(entries, catalogEntry{ID: metadata.Id, Slug: metadata.Slug, Name: metadata.Name})
+		}
+
+		return entries, out.Data.GetPagination().Next.Get(), nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return findCatalogID("Source", slug, name, entries)
+}
+
+func findDestinationMetadataID(authContext context.Context, client *api.APIClient, catalog *catalogCache, slug string, name string) (string, error) {
+	entries, err := catalog.list("Destination", func(pagination api.PaginationInput) ([]catalogEntry, *string, error) {
+		out, body, err := client.CatalogAPI.GetDestinationsCatalog(authContext).Pagination(pagination).Execute()
+		if body != nil {
+			defer body.Body.Close()
+		}
+		if err != nil {
+			return nil, nil, errors.New(getError(err, body))
+		}
+
+		entries := []catalogEntry{}
+		for _, metadata := range out.Data.GetDestinationsCatalog() {
+			entries = append(entries, catalogEntry{ID: metadata.Id, Slug: metadata.Slug, Name: metadata.Name})
+		}
+
+		return entries, out.Data.GetPagination().Next.Get(), nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return findCatalogID("Destination", slug, name, entries)
+}
+
+func findWarehouseMetadataID(authContext context.Context, client *api.APIClient, catalog *catalogCache, slug string, name string) (string, error) {
+	entries, err := catalog.list("Warehouse", func(pagination api.PaginationInput) ([]catalogEntry, *string, error) {
+		out, body, err := client.CatalogAPI.GetWarehousesCatalog(authContext).Pagination(pagination).Execute()
+		if body != nil {
+			defer body.Body.Close()
+		}
+		if err != nil {
+			return nil, nil, errors.New(getError(err, body))
+		}
+
+		entries := []catalogEntry{}
+		for _, metadata := range out.Data.GetWarehousesCatalog() {
+			entries = append(entries, catalogEntry{ID: metadata.Id, Slug: metadata.Slug, Name: metadata.Name})
+		}
+
+		return entries, out.Data.GetPagination().Next.Get(), nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return findCatalogID("Warehouse", slug, name, entries)
+}
+
+// planMetadataID resolves `metadata.id` from `metadata.slug` or `metadata.name` when it is not configured,
+// and requires the resource to be replaced only when the resolved id differs from the current one.
+func planMetadataID(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, find func(slug string, name string) (string, error)) {
+	// Nothing to do when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	idPath := path.Root("metadata").AtName("id")
+
+	var id, slug, name types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, idPath, &id)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("metadata").AtName("slug"), &slug)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("metadata").AtName("name"), &name)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if id.IsNull() {
+		switch {
+		case slug.IsUnknown() || name.IsUnknown():
+			id = types.StringUnknown()
+		case !slug.IsNull() || !name.IsNull():
+			resolvedID, err := find(slug.ValueString(), name.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("metadata"), "Unable to find metadata in the Segment catalog", err.Error())
+
+				return
+			}
+			id = types.StringValue(resolvedID)
+		default:
+			return
+		}
+
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, idPath, id)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Nothing is replaced when the resource is created
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var stateID types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, idPath, &stateID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if id.IsUnknown() || id.ValueString() != stateID.ValueString() {
+		resp.RequiresReplace.Append(idPath)
+	}
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/segmentio/public-api-sdk-go/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindCatalogID(t *testing.T) {
+	t.Parallel()
+
+	pages := [][]catalogEntry{
+		{
+			{ID: "id-1", Slug: "google-analytics", Name: "Google Analytics"},
+			{ID: "id-2", Slug: "amplitude", Name: "Amplitude"},
+		},
+		{
+			{ID: "id-3", Slug: "amplitude-actions", Name: "Amplitude"},
+			{ID: "id-4", Slug: "mixpanel", Name: "Mixpanel"},
+		},
+	}
+	entries := append(append([]catalogEntry{}, pages[0]...), pages[1]...)
+
+	t.Run("by slug", func(t *testing.T) {
+		t.Parallel()
+		id, err := findCatalogID("Destination", "mixpanel", "", entries)
+
+		require.NoError(t, err)
+		assert.Equal(t, "id-4", id)
+	})
+
+	t.Run("by name", func(t *testing.T) {
+		t.Parallel()
+		id, err := findCatalogID("Destination", "", "Google Analytics", entries)
+
+		require.NoError(t, err)
+		assert.Equal(t, "id-1", id)
+	})
+
+	t.Run("ambiguous name", func(t *testing.T) {
+		t.Parallel()
+		_, err := findCatalogID("Destination", "", "Amplitude", entries)
+
+		require.ErrorContains(t, err, "amplitude, amplitude-actions")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		_, err := findCatalogID("Destination", "segment-unknown", "", entries)
+
+		require.ErrorContains(t, err, `no Destination with slug "segment-unknown"`)
+	})
+}
+
+func TestCatalogCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("lists every page once", func(t *testing.T) {
+		t.Parallel()
+		calls := 0
+		listPage := func(pagination api.PaginationInput) ([]catalogEntry, *string, error) {
+			calls++
+			if pagination.Cursor == nil {
+				next := "page-2"
+
+				return []catalogEntry{{ID: "id-1", Slug: "google-analytics"}}, &next, nil
+			}
+
+			return []catalogEntry{{ID: "id-2", Slug: "mixpanel"}}, nil, nil
+		}
+
+		var cache catalogCache
+		for i := 0; i < 3; i++ {
+			entries, err := cache.list("Destination", listPage)
+
+			require.NoError(t, err)
+			assert.Len(t, entries, 2)
+		}
+		assert.Equal(t, 2, calls)
+
+		// Catalogs of other kinds are listed separately
+		entries, err := cache.list("Source", listPage)
+		require.NoError(t, err)
+		assert.Len(t, entries, 2)
+		assert.Equal(t, 4, calls)
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		t.Parallel()
+		var cache catalogCache
+		_, err := cache.list("Destination", func(_ api.PaginationInput) ([]catalogEntry, *string, error) {
+			return nil, nil, errors.New("boom")
+		})
+		require.EqualError(t, err, "boom")
+
+		entries, err := cache.list("Destination", func(_ api.PaginationInput) ([]catalogEntry, *string, error) {
+			return []catalogEntry{{ID: "id-1"}}, nil, nil
+		})
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+}
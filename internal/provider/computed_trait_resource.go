@@ -0,0 +1,303 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+
+	"github.com/segmentio/public-api-sdk-go/api"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/docs"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
+)
+
+var (
+	_ resource.Resource                = &computedTraitResource{}
+	_ resource.ResourceWithConfigure   = &computedTraitResource{}
+	_ resource.ResourceWithImportState = &computedTraitResource{}
+)
+
+func NewComputedTraitResource() resource.Resource {
+	return &computedTraitResource{}
+}
+
+type computedTraitResource struct {
+	client      *api.APIClient
+	authContext context.Context
+}
+
+func (r *computedTraitResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_computed_trait"
+}
+
+func (r *computedTraitResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Configures an Engage Computed Trait in a Space, which computes a trait of each profile from its events. For more information, visit the [Segment docs](https://segment.com/docs/unify/traits/computed-traits/).\n\n" +
+			docs.GenerateImportDocs("<space_id>:<computed_trait_id>", "segment_computed_trait"),
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier of the Computed Trait.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The id of the Space, such as `data.segment_space.example.id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the Computed Trait.",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "The description of the Computed Trait.",
+			},
+			"key": schema.StringAttribute{
+				Computed:    true,
+				Description: "The key of the Computed Trait, used as the trait name on the profiles and in the Destinations it is sent to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Required:    true,
+				Description: "Whether the Computed Trait is computed.",
+			},
+			"definition": computationDefinitionSchema("Computed Trait", "The query that aggregates the events of a profile into the value of the Computed Trait.", "`event('Order Completed').sum(property('total'))`"),
+			"include_historical_data": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether the data collected before the Computed Trait was created is used to compute its values. Segment can include historical data when the definition requires it, in which case the configured value is kept. Changing this forces a new Computed Trait to be created.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"include_anonymous_users": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether the Computed Trait is computed for anonymous profiles. Changing this forces a new Computed Trait to be created.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"created_by": schema.StringAttribute{
+				Computed:    true,
+				Description: "The id of the user who created the Computed Trait.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_by": schema.StringAttribute{
+				Computed:    true,
+				Description: "The id of the user who last updated the Computed Trait, in Terraform or in the Segment app.",
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time the Computed Trait was last updated.",
+			},
+		},
+	}
+}
+
+func (r *computedTraitResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan models.ComputedTraitState
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	out, body, err := r.client.ComputedTraitsAPI.CreateComputedTrait(r.authContext, plan.SpaceID.ValueString()).CreateComputedTraitAlphaInput(api.CreateComputedTraitAlphaInput{
+		Name:        plan.Name.ValueString(),
+		Enabled:     plan.Enabled.ValueBoolPointer(),
+		Description: plan.Description.ValueStringPointer(),
+		Definition:  plan.Definition.ToTraitAPIValue(),
+		Options: &api.TraitOptions{
+			IncludeHistoricalData: plan.IncludeHistoricalData.ValueBoolPointer(),
+			IncludeAnonymousUsers: plan.IncludeAnonymousUsers.ValueBoolPointer(),
+		},
+	}).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to create Computed Trait in Space (ID: %s)", plan.SpaceID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+
+	state := plan
+	state.Fill(out.Data.ComputedTrait)
+	state.IncludeHistoricalData = configuredIncludeHistoricalData(state.IncludeHistoricalData, plan.IncludeHistoricalData)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *computedTraitResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var previousState models.ComputedTraitState
+	diags := req.State.Get(ctx, &previousState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	out, body, err := r.client.ComputedTraitsAPI.GetComputedTrait(r.authContext, previousState.SpaceID.ValueString(), previousState.ID.ValueString()).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		if body != nil && body.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Computed Trait (ID: %s)", previousState.ID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+
+	state := previousState
+	state.Fill(out.Data.ComputedTrait)
+	state.IncludeHistoricalData = configuredIncludeHistoricalData(state.IncludeHistoricalData, previousState.IncludeHistoricalData)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *computedTraitResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan models.ComputedTraitState
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state models.ComputedTraitState
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	definition := plan.Definition.ToTraitAPIValue()
+	input := api.UpdateComputedTraitForSpaceAlphaInput{
+		Name:        plan.Name.ValueStringPointer(),
+		Enabled:     plan.Enabled.ValueBoolPointer(),
+		Description: plan.Description.ValueStringPointer(),
+		Definition:  &definition,
+	}
+	// An omitted description is left untouched, so it is cleared explicitly
+	if plan.Description.IsNull() && !state.Description.IsNull() {
+		input.Description = api.PtrString("")
+	}
+
+	out, body, err := r.client.ComputedTraitsAPI.UpdateComputedTraitForSpace(r.authContext, state.SpaceID.ValueString(), state.ID.ValueString()).UpdateComputedTraitForSpaceAlphaInput(input).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to update Computed Trait (ID: %s)", state.ID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+
+	previousIncludeHistoricalData := state.IncludeHistoricalData
+	state.Fill(out.Data.ComputedTrait)
+	state.IncludeHistoricalData = configuredIncludeHistoricalData(state.IncludeHistoricalData, previousIncludeHistoricalData)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *computedTraitResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var config models.ComputedTraitState
+	diags := req.State.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, body, err := r.client.ComputedTraitsAPI.RemoveComputedTraitFromSpace(r.authContext, config.SpaceID.ValueString(), config.ID.ValueString()).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to delete Computed Trait (ID: %s)", config.ID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+}
+
+func (r *computedTraitResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, ":")
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <space_id>:<computed_trait_id>. Got: %q", req.ID),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("space_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
+}
+
+func (r *computedTraitResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected ClientInfo, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = config.client
+	r.authContext = config.authContext
+}
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/segmentio/public-api-sdk-go/api"
+)
+
+func TestAccComputedTraitResource(t *testing.T) {
+	t.Parallel()
+
+	computedTrait := map[string]interface{}{}
+
+	fakeServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("content-type", "application/json")
+
+			var payload map[string]interface{}
+			if req.URL.Path == "/spaces/my-space-id/computed-traits" && req.Method == http.MethodPost {
+				var input api.CreateComputedTraitAlphaInput
+				_ = json.NewDecoder(req.Body).Decode(&input)
+
+				computedTrait = map[string]interface{}{
+					"id":          "my-computed-trait-id",
+					"spaceId":     "my-space-id",
+					"name":        input.Name,
+					"description": input.Description,
+					"key":         "lifetime_value",
+					"enabled":     *input.Enabled,
+					"definition":  map[string]interface{}{"query": input.Definition.Query, "type": input.Definition.Type},
+					"status":      "Computing",
+					"createdBy":   "my-user-id",
+					"updatedBy":   "my-user-id",
+					"createdAt":   "2024-01-01T00:00:00.000Z",
+					"updatedAt":   "2024-01-01T00:00:00.000Z",
+					"options":     map[string]interface{}{"includeHistoricalData": *input.Options.IncludeHistoricalData, "includeAnonymousUsers": *input.Options.IncludeAnonymousUsers},
+				}
+				payload = map[string]interface{}{"computedTrait": computedTrait}
+			} else if req.URL.Path == "/spaces/my-space-id/computed-traits/my-computed-trait-id" && req.Method == http.MethodPatch {
+				var input api.UpdateComputedTraitForSpaceAlphaInput
+				_ = json.NewDecoder(req.Body).Decode(&input)
+
+				computedTrait["name"] = *input.Name
+				computedTrait["enabled"] = *input.Enabled
+				computedTrait["description"] = input.Description
+				computedTrait["definition"] = map[string]interface{}{"query": input.Definition.Query, "type": input.Definition.Type}
+				computedTrait["updatedBy"] = "my-other-user-id"
+				computedTrait["updatedAt"] = "2024-02-01T00:00:00.000Z"
+				// The options are omitted by the API once the Computed Trait is updated, which keeps the previous values
+				delete(computedTrait, "options")
+				payload = map[string]interface{}{"computedTrait": computedTrait}
+			} else if req.URL.Path == "/spaces/my-space-id/computed-traits/my-computed-trait-id" && req.Method == http.MethodDelete {
+				payload = map[string]interface{}{"status": "SUCCESS"}
+			} else if req.URL.Path == "/spaces/my-space-id/computed-traits/my-computed-trait-id" {
+				payload = map[string]interface{}{"computedTrait": computedTrait}
+			}
+
+			out, _ := json.Marshal(map[string]interface{}{"data": payload})
+			_, _ = w.Write(out)
+		}),
+	)
+	defer fakeServer.Close()
+
+	providerConfig := `
+		provider "segment" {
+			url   = "` + fakeServer.URL + `"
+			token = "abc123"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+					resource "segment_computed_trait" "test" {
+						space_id    = "my-space-id"
+						name        = "Lifetime value"
+						description = "Total amount of the completed orders"
+						enabled     = true
+						definition = {
+							query = "event('Order Completed').sum(property('total'))"
+						}
+						include_historical_data = true
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_computed_trait.test", "id", "my-computed-trait-id"),
+					resource.TestCheckResourceAttr("segment_computed_trait.test", "key", "lifetime_value"),
+					resource.TestCheckResourceAttr("segment_computed_trait.test", "description", "Total amount of the completed orders"),
+					resource.TestCheckResourceAttr("segment_computed_trait.test", "definition.type", "users"),
+					resource.TestCheckResourceAttr("segment_computed_trait.test", "include_historical_data", "true"),
+					resource.TestCheckResourceAttr("segment_computed_trait.test", "include_anonymous_users", "false"),
+					resource.TestCheckResourceAttr("segment_computed_trait.test", "created_by", "my-user-id"),
+					resource.TestCheckResourceAttr("segment_computed_trait.test", "updated_by", "my-user-id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "segment_computed_trait.test",
+				ImportState:       true,
+				ImportStateId:     "my-space-id:my-computed-trait-id",
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+					resource "segment_computed_trait" "test" {
+						space_id = "my-space-id"
+						name     = "Lifetime value"
+						enabled  = true
+						definition = {
+							query = "event('Order Completed').sum(property('revenue'))"
+						}
+						include_historical_data = true
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("segment_computed_trait.test", "description"),
+					resource.TestCheckResourceAttr("segment_computed_trait.test", "definition.query", "event('Order Completed').sum(property('revenue'))"),
+					resource.TestCheckResourceAttr("segment_computed_trait.test", "created_by", "my-user-id"),
+					resource.TestCheckResourceAttr("segment_computed_trait.test", "updated_by", "my-other-user-id"),
+					resource.TestCheckResourceAttr("segment_computed_trait.test", "updated_at", "2024-02-01T00:00:00.000Z"),
+					resource.TestCheckResourceAttr("segment_computed_trait.test", "include_historical_data", "true"),
+					resource.TestCheckResourceAttr("segment_computed_trait.test", "include_anonymous_users", "false"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,375 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+
+	"github.com/segmentio/public-api-sdk-go/api"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
+)
+
+var (
+	_ datasource.DataSource              = &destinationChildrenDataSource{}
+	_ datasource.DataSourceWithConfigure = &destinationChildrenDataSource{}
+)
+
+func NewDestinationChildrenDataSource() datasource.DataSource {
+	return &destinationChildrenDataSource{}
+}
+
+type destinationChildrenDataSource struct {
+	client      *api.APIClient
+	authContext context.Context
+}
+
+func (d *destinationChildrenDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_destination_children"
+}
+
+func (d *destinationChildrenDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads every subscription, Destination filter and insert Function instance of a Destination. Reading insert Function instances lists the instances of every insert Function of the Workspace.",
+		Attributes: map[string]schema.Attribute{
+			"destination_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The id of the Destination.",
+			},
+			"subscriptions": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The subscriptions of the Destination.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier for the subscription.",
+						},
+						"destination_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The associated Destination instance id.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the subscription.",
+						},
+						"enabled": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Is the subscription enabled.",
+						},
+						"action_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier for the Destination action to trigger.",
+						},
+						"action_slug": schema.StringAttribute{
+							Computed:    true,
+							Description: "The URL-friendly key for the associated Destination action.",
+						},
+						"trigger": schema.StringAttribute{
+							Computed:    true,
+							Description: "FQL string that describes what events should trigger a Destination action.",
+						},
+						"model_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier for the linked ReverseETLModel, if this part of a Reverse ETL connection.",
+						},
+						"settings": schema.StringAttribute{
+							Computed:    true,
+							Description: "The customer settings for action fields.",
+							CustomType:  jsontypes.NormalizedType{},
+						},
+						"reverse_etl_schedule": reverseETLScheduleDataSourceSchema(),
+					},
+				},
+			},
+			"filters": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The Destination filters of the Destination.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier for the Destination filter.",
+						},
+						"if": schema.StringAttribute{
+							Computed:    true,
+							Description: "The filter's condition.",
+						},
+						"destination_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The id of the Destination associated with this filter.",
+						},
+						"source_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The id of the Source associated with this filter.",
+						},
+						"title": schema.StringAttribute{
+							Computed:    true,
+							Description: "The title of the filter.",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "The description of the filter.",
+						},
+						"enabled": schema.BoolAttribute{
+							Computed:    true,
+							Description: "When set to true, the Destination filter is active.",
+						},
+						"actions": schema.ListNestedAttribute{
+							Computed:    true,
+							Description: "Actions for the Destination filter.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"type": schema.StringAttribute{
+										Computed:    true,
+										Description: "The kind of Transformation to apply to any matched properties.",
+									},
+									"percent": schema.Float64Attribute{
+										Computed:    true,
+										Description: "A decimal between 0 and 1 used for 'sample' type events and influences the likelihood of sampling to occur.",
+									},
+									"path": schema.StringAttribute{
+										Computed:    true,
+										Description: "The JSON path to a property within a payload object from which Segment generates a deterministic sampling rate.",
+									},
+									"fields": schema.StringAttribute{
+										Computed:    true,
+										Description: "A dictionary of paths to object keys that this filter applies to.",
+										CustomType:  jsontypes.NormalizedType{},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"insert_function_instances": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The insert Function instances connected to the Destination.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier for the insert Function instance.",
+						},
+						"function_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The id of the insert Function class this instance is created from.",
+						},
+						"integration_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The id of the Destination this insert Function instance is connected to.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the insert Function instance.",
+						},
+						"enabled": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether this insert Function instance should be enabled for the Destination.",
+						},
+						"settings": schema.StringAttribute{
+							Computed:    true,
+							Description: "The settings of the insert Function instance.",
+							CustomType:  jsontypes.NormalizedType{},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *destinationChildrenDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state models.DestinationChildrenState
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	destinationID := state.DestinationID.ValueString()
+
+	subscriptions, _, err := listDestinationSubscriptions(d.authContext, d.client, destinationID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Destination subscriptions (ID: %s)", destinationID),
+			err.Error(),
+		)
+
+		return
+	}
+
+	state.Subscriptions = []models.DestinationSubscriptionState{}
+	for _, subscription := range subscriptions {
+		var subscriptionState models.DestinationSubscriptionState
+		err = subscriptionState.Fill(subscription)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to populate Destination subscription state",
+				err.Error(),
+			)
+
+			return
+		}
+		state.Subscriptions = append(state.Subscriptions, subscriptionState)
+	}
+
+	filters, err := listDestinationFilters(d.authContext, d.client, destinationID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Destination filters (ID: %s)", destinationID),
+			err.Error(),
+		)
+
+		return
+	}
+
+	state.Filters = []models.DestinationFilterState{}
+	for _, filter := range filters {
+		var filterState models.DestinationFilterState
+		err = filterState.Fill(&filter)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to populate Destination filter state",
+				err.Error(),
+			)
+
+			return
+		}
+		state.Filters = append(state.Filters, filterState)
+	}
+
+	instances, err := listDestinationInsertFunctionInstances(d.authContext, d.client, destinationID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read insert Function instances of Destination (ID: %s)", destinationID),
+			err.Error(),
+		)
+
+		return
+	}
+
+	state.InsertFunctionInstances = []models.InsertFunctionInstanceState{}
+	for _, instance := range instances {
+		var instanceState models.InsertFunctionInstanceState
+		err = instanceState.Fill(instance)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to populate insert Function instance state",
+				err.Error(),
+			)
+
+			return
+		}
+		state.InsertFunctionInstances = append(state.InsertFunctionInstances, instanceState)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (d *destinationChildrenDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientInfo, ok := req.ProviderData.(*ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ClientInfo, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = clientInfo.client
+	d.authContext = clientInfo.authContext
+}
+
+func listDestinationFilters(authContext context.Context, client *api.APIClient, destinationID string) ([]api.DestinationFilterV1, error) {
+	filters := []api.DestinationFilterV1{}
+	paginationInput := *api.NewPaginationInput(MaxPageSize)
+
+	for {
+		out, body, err := client.DestinationFiltersAPI.ListFiltersFromDestination(authContext, destinationID).Pagination(paginationInput).Execute()
+		if body != nil {
+			defer body.Body.Close()
+		}
+		if err != nil {
+			return nil, errors.New(getError(err, body))
+		}
+
+		filters = append(filters, out.Data.GetFilters()...)
+
+		next := out.Data.GetPagination().Next.Get()
+		if next == nil {
+			return filters, nil
+		}
+		paginationInput.SetCursor(*next)
+	}
+}
+
+// listDestinationInsertFunctionInstances returns the insert Function instances connected to the Destination.
+// Instances can only be listed by insert Function, so the instances of every insert Function of the Workspace are listed.
+func listDestinationInsertFunctionInstances(authContext context.Context, client *api.APIClient, destinationID string) ([]api.InsertFunctionInstanceAlpha, error) {
+	functionIDs := []string{}
+	paginationInput := *api.NewPaginationInput(MaxPageSize)
+
+	for {
+		out, body, err := client.FunctionsAPI.ListFunctions(authContext).ResourceType("INSERT_DESTINATION").Pagination(paginationInput).Execute()
+		if body != nil {
+			defer body.Body.Close()
+		}
+		if err != nil {
+			return nil, errors.New(getError(err, body))
+		}
+
+		for _, function := range out.Data.GetFunctions() {
+			functionIDs = append(functionIDs, function.GetId())
+		}
+
+		next := out.Data.GetPagination().Next.Get()
+		if next == nil {
+			break
+		}
+		paginationInput.SetCursor(*next)
+	}
+
+	instances := []api.InsertFunctionInstanceAlpha{}
+	for _, functionID := range functionIDs {
+		paginationInput := *api.NewPaginationInput(MaxPageSize)
+
+		for {
+			out, body, err := client.FunctionsAPI.ListInsertFunctionInstances(authContext).FunctionId(functionID).Pagination(paginationInput).Execute()
+			if body != nil {
+				defer body.Body.Close()
+			}
+			if err != nil {
+				return nil, errors.New(getError(err, body))
+			}
+
+			for _, instance := range out.Data.GetInstances() {
+				if instance.IntegrationId == destinationID {
+					instances = append(instances, instance)
+				}
+			}
+
+			next := out.Data.GetPagination().Next.Get()
+			if next == nil {
+				break
+			}
+			paginationInput.SetCursor(*next)
+		}
+	}
+
+	return instances, nil
+}
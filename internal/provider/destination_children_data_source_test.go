@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDestinationChildrenDataSource(t *testing.T) {
+	t.Parallel()
+
+	fakeServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("content-type", "application/json")
+
+			payload := ""
+			if req.URL.Path == "/destinations/my-destination-id/subscriptions" {
+				payload = `
+					{
+						"data": {
+							"subscriptions": [
+								{
+									"id": "my-subscription-id",
+									"name": "My subscription",
+									"actionId": "my-action-id",
+									"actionSlug": "my-action-slug",
+									"destinationId": "my-destination-id",
+									"enabled": true,
+									"settings": {
+										"url": "https://example.com"
+									},
+									"trigger": "type = \"track\""
+								}
+							],
+							"pagination": {
+								"current": "MA=="
+							}
+						}
+					}
+				`
+			} else if req.URL.Path == "/destination/my-destination-id/filters" {
+				payload = `
+					{
+						"data": {
+							"filters": [
+								{
+									"id": "my-filter-id",
+									"sourceId": "my-source-id",
+									"destinationId": "my-destination-id",
+									"if": "type = \"identify\"",
+									"actions": [
+										{
+											"type": "DROP"
+										}
+									],
+									"title": "My filter",
+									"enabled": true
+								}
+							],
+							"pagination": {
+								"current": "MA=="
+							}
+						}
+					}
+				`
+			} else if req.URL.Path == "/functions" {
+				payload = `
+					{
+						"data": {
+							"functions": [
+								{
+									"id": "my-function-id",
+									"resourceType": "INSERT_DESTINATION"
+								}
+							],
+							"pagination": {
+								"current": "MA=="
+							}
+						}
+					}
+				`
+			} else if req.URL.Path == "/insert-function-instances" {
+				payload = `
+					{
+						"data": {
+							"instances": [
+								{
+									"id": "my-instance-id",
+									"name": "My instance",
+									"integrationId": "my-destination-id",
+									"classId": "my-function-id",
+									"enabled": true,
+									"createdAt": "2024-01-01T00:00:00Z",
+									"updatedAt": "2024-01-01T00:00:00Z",
+									"settings": {},
+									"encryptedSettings": {}
+								},
+								{
+									"id": "my-other-instance-id",
+									"name": "My other instance",
+									"integrationId": "my-other-destination-id",
+									"classId": "my-function-id",
+									"enabled": true,
+									"createdAt": "2024-01-01T00:00:00Z",
+									"updatedAt": "2024-01-01T00:00:00Z",
+									"settings": {},
+									"encryptedSettings": {}
+								}
+							],
+							"pagination": {
+								"current": "MA=="
+							}
+						}
+					}
+				`
+			}
+
+			_, _ = w.Write([]byte(payload))
+		}),
+	)
+	defer fakeServer.Close()
+
+	providerConfig := `
+		provider "segment" {
+			url   = "` + fakeServer.URL + `"
+			token = "abc123"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `data "segment_destination_children" "test" { destination_id = "my-destination-id" }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.segment_destination_children.test", "destination_id", "my-destination-id"),
+					resource.TestCheckResourceAttr("data.segment_destination_children.test", "subscriptions.#", "1"),
+					resource.TestCheckResourceAttr("data.segment_destination_children.test", "subscriptions.0.id", "my-subscription-id"),
+					resource.TestCheckResourceAttr("data.segment_destination_children.test", "subscriptions.0.settings", "{\"url\":\"https://example.com\"}"),
+					resource.TestCheckResourceAttr("data.segment_destination_children.test", "filters.#", "1"),
+					resource.TestCheckResourceAttr("data.segment_destination_children.test", "filters.0.id", "my-filter-id"),
+					resource.TestCheckResourceAttr("data.segment_destination_children.test", "filters.0.actions.0.type", "DROP"),
+					resource.TestCheckResourceAttr("data.segment_destination_children.test", "insert_function_instances.#", "1"),
+					resource.TestCheckResourceAttr("data.segment_destination_children.test", "insert_function_instances.0.id", "my-instance-id"),
+					resource.TestCheckResourceAttr("data.segment_destination_children.test", "insert_function_instances.0.function_id", "my-function-id"),
+				),
+			},
+		},
+	})
+}
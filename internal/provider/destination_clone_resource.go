@@ -0,0 +1,616 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/segmentio/public-api-sdk-go/api"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
+)
+
+var (
+	_ resource.Resource              = &destinationCloneResource{}
+	_ resource.ResourceWithConfigure = &destinationCloneResource{}
+)
+
+func NewDestinationCloneResource() resource.Resource {
+	return &destinationCloneResource{}
+}
+
+type destinationCloneResource struct {
+	client      *api.APIClient
+	authContext context.Context
+}
+
+func (r *destinationCloneResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_destination_clone"
+}
+
+func (r *destinationCloneResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	copiedChildAttributes := func(nameDescription string) map[string]schema.Attribute {
+		return map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The id of the copy.",
+			},
+			"copied_from_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The id of the copied item of the template Destination.",
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: nameDescription,
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Creates a Destination on a Source as a copy of an existing Destination, along with its subscriptions and Destination filters. The copy is made once when the resource is created; later changes to the template Destination are not applied. Settings that the API masks in the template Destination and its subscriptions, such as API keys, cannot be copied: they are listed in `masked_settings` and should be supplied in `settings` and `subscription_settings`. Reverse ETL subscriptions are not copied. Subscriptions and Destination filters of the copy that are removed outside of Terraform are no longer listed and are not copied again unless the resource is replaced. This resource cannot be imported. For more information, visit the [Segment docs](https://segment.com/docs/connections/destinations/).",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The id of the created Destination.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"copy_from_destination_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The id of the Destination to copy.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The id of the Source to create the Destination on.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the created Destination. Defaults to the name of the template Destination.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Required:    true,
+				Description: "Whether the created Destination receives data.",
+			},
+			"settings": schema.StringAttribute{
+				Optional:    true,
+				Description: "Settings that override the ones copied from the template Destination, such as the ones listed in `masked_settings`. Only settings included in the configuration will be managed by Terraform.",
+				CustomType:  jsontypes.NormalizedType{},
+			},
+			"subscription_settings": schema.MapAttribute{
+				Optional:    true,
+				ElementType: jsontypes.NormalizedType{},
+				Description: "Settings that override the ones copied from the subscriptions of the template Destination, such as the ones listed in `masked_settings`, keyed by subscription name. Changing this value forces a new Destination to be copied.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"metadata_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The id of the Destination metadata of the template Destination.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"masked_settings": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The settings that could not be copied because the API masks them, and were not supplied in `settings`. Masked settings of subscriptions that were not supplied in `subscription_settings` are listed with the format `<subscription_name>:<setting>`.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"subscriptions": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The subscriptions copied from the template Destination that still exist, as of the last refresh.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: copiedChildAttributes("The name of the subscription."),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"filters": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The Destination filters copied from the template Destination that still exist, as of the last refresh.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: copiedChildAttributes("The title of the Destination filter."),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *destinationCloneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan models.DestinationClonePlan
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	templateID := plan.CopyFromDestinationID.ValueString()
+
+	templateOut, body, err := r.client.DestinationsAPI.GetDestination(r.authContext, templateID).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Destination (ID: %s)", templateID),
+			getError(err, body),
+		)
+
+		return
+	}
+	template := templateOut.Data.Destination
+
+	var overrides map[string]interface{}
+	if !plan.Settings.IsNull() {
+		diags = plan.Settings.Unmarshal(&overrides)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	subscriptions, _, err := listDestinationSubscriptions(r.authContext, r.client, templateID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Destination subscriptions (ID: %s)", templateID),
+			err.Error(),
+		)
+
+		return
+	}
+
+	subscriptionOverrides := map[string]map[string]interface{}{}
+	if !plan.SubscriptionSettings.IsNull() {
+		var subscriptionSettings map[string]jsontypes.Normalized
+		diags = plan.SubscriptionSettings.ElementsAs(ctx, &subscriptionSettings, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		for name, value := range subscriptionSettings {
+			found := slices.ContainsFunc(subscriptions, func(subscription api.DestinationSubscription) bool {
+				return subscription.Name == name && (subscription.ModelId == nil || *subscription.ModelId == "")
+			})
+			if !found {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("subscription_settings").AtMapKey(name),
+					"Unknown subscription",
+					fmt.Sprintf("Destination (ID: %s) has no subscription named %q to copy.", templateID, name),
+				)
+
+				continue
+			}
+
+			var override map[string]interface{}
+			resp.Diagnostics.Append(value.Unmarshal(&override)...)
+			subscriptionOverrides[name] = override
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	settings, maskedKeys := withoutMaskedSettings(template.Settings)
+	maskedSettings := []types.String{}
+	for _, key := range maskedKeys {
+		if _, ok := overrides[key]; !ok {
+			maskedSettings = append(maskedSettings, types.StringValue(key))
+		}
+	}
+	for key, value := range overrides {
+		settings[key] = value
+	}
+
+	name := plan.Name.ValueStringPointer()
+	if plan.Name.IsUnknown() || plan.Name.IsNull() {
+		name = template.Name
+	}
+
+	out, body, err := r.client.DestinationsAPI.CreateDestination(r.authContext).CreateDestinationV1Input(api.CreateDestinationV1Input{
+		SourceId:   plan.SourceID.ValueString(),
+		MetadataId: template.Metadata.Id,
+		Enabled:    plan.Enabled.ValueBoolPointer(),
+		Name:       name,
+		Settings:   settings,
+	}).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create Destination",
+			getError(err, body),
+		)
+
+		return
+	}
+
+	destination := out.Data.Destination
+	resp.State.SetAttribute(ctx, path.Root("id"), destination.Id)
+
+	state := models.DestinationCloneState{
+		ID:                    types.StringValue(destination.Id),
+		CopyFromDestinationID: plan.CopyFromDestinationID,
+		SourceID:              plan.SourceID,
+		Name:                  types.StringPointerValue(destination.Name),
+		Enabled:               types.BoolValue(destination.Enabled),
+		Settings:              plan.Settings,
+		SubscriptionSettings:  plan.SubscriptionSettings,
+		MetadataID:            types.StringValue(destination.Metadata.Id),
+		MaskedSettings:        maskedSettings,
+		Subscriptions:         []models.CopiedDestinationChild{},
+		Filters:               []models.CopiedDestinationChild{},
+	}
+
+	for _, subscription := range subscriptions {
+		// Reverse ETL subscriptions belong to the models of the template Source
+		if subscription.ModelId != nil && *subscription.ModelId != "" {
+			resp.Diagnostics.AddWarning(
+				"Reverse ETL subscription not copied",
+				fmt.Sprintf("Subscription %q (ID: %s) is linked to a Reverse ETL model and was not copied.", subscription.Name, subscription.Id),
+			)
+
+			continue
+		}
+
+		subscriptionSettings, maskedKeys := withoutMaskedSettings(subscription.Settings)
+		overrides := subscriptionOverrides[subscription.Name]
+		for _, key := range maskedKeys {
+			if _, ok := overrides[key]; !ok {
+				state.MaskedSettings = append(state.MaskedSettings, types.StringValue(subscription.Name+":"+key))
+			}
+		}
+		for key, value := range overrides {
+			subscriptionSettings[key] = value
+		}
+
+		subscriptionOut, body, err := r.client.DestinationsAPI.CreateDestinationSubscription(r.authContext, destination.Id).CreateDestinationSubscriptionAlphaInput(api.CreateDestinationSubscriptionAlphaInput{
+			Name:     subscription.Name,
+			ActionId: subscription.ActionId,
+			Trigger:  subscription.Trigger,
+			Enabled:  subscription.Enabled,
+			Settings: subscriptionSettings,
+		}).Execute()
+		if body != nil {
+			defer body.Body.Close()
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Unable to copy Destination subscription (ID: %s)", subscription.Id),
+				getError(err, body),
+			)
+
+			return
+		}
+
+		state.Subscriptions = append(state.Subscriptions, models.CopiedDestinationChild{
+			ID:           types.StringValue(subscriptionOut.Data.DestinationSubscription.Id),
+			CopiedFromID: types.StringValue(subscription.Id),
+			Name:         types.StringValue(subscription.Name),
+		})
+	}
+
+	filters, err := listDestinationFilters(r.authContext, r.client, templateID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Destination filters (ID: %s)", templateID),
+			err.Error(),
+		)
+
+		return
+	}
+
+	for _, filter := range filters {
+		filterOut, body, err := r.client.DestinationFiltersAPI.CreateFilterForDestination(r.authContext, destination.Id).CreateFilterForDestinationV1Input(api.CreateFilterForDestinationV1Input{
+			SourceId:    plan.SourceID.ValueString(),
+			If:          filter.If,
+			Title:       filter.Title,
+			Description: filter.Description,
+			Enabled:     filter.Enabled,
+			Actions:     filter.Actions,
+		}).Execute()
+		if body != nil {
+			defer body.Body.Close()
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Unable to copy Destination filter (ID: %s)", filter.Id),
+				getError(err, body),
+			)
+
+			return
+		}
+
+		state.Filters = append(state.Filters, models.CopiedDestinationChild{
+			ID:           types.StringValue(filterOut.Data.Filter.Id),
+			CopiedFromID: types.StringValue(filter.Id),
+			Name:         types.StringValue(filter.Title),
+		})
+	}
+
+	if len(state.MaskedSettings) > 0 {
+		keys := []string{}
+		for _, key := range state.MaskedSettings {
+			keys = append(keys, key.ValueString())
+		}
+
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("masked_settings"),
+			"Masked settings not copied",
+			fmt.Sprintf("The following settings of Destination (ID: %s) are masked by the API and were not copied: %s. Supply them in settings or subscription_settings.", templateID, strings.Join(keys, ", ")),
+		)
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *destinationCloneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state models.DestinationCloneState
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	out, body, err := r.client.DestinationsAPI.GetDestination(r.authContext, state.ID.ValueString()).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		if body.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Destination (ID: %s)", state.ID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+
+	destination := out.Data.Destination
+	state.Name = types.StringPointerValue(destination.Name)
+	state.Enabled = types.BoolValue(destination.Enabled)
+	state.MetadataID = types.StringValue(destination.Metadata.Id)
+
+	// Only the settings overridden in the configuration are kept
+	if !state.Settings.IsNull() && !state.Settings.IsUnknown() {
+		remoteSettings, err := models.GetSettingsFromMap(destination.Settings)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to populate Destination state",
+				err.Error(),
+			)
+
+			return
+		}
+
+		mergedSettings, err := mergeSettings(state.Settings, remoteSettings, false)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to merge Destination settings",
+				err.Error(),
+			)
+
+			return
+		}
+		state.Settings = mergedSettings
+	}
+
+	// Copies removed outside of Terraform are no longer tracked, as they are only copied when the resource is created
+	subscriptions, _, err := listDestinationSubscriptions(r.authContext, r.client, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Destination subscriptions (ID: %s)", state.ID.ValueString()),
+			err.Error(),
+		)
+
+		return
+	}
+	subscriptionNames := map[string]string{}
+	for _, subscription := range subscriptions {
+		subscriptionNames[subscription.Id] = subscription.Name
+	}
+
+	filters, err := listDestinationFilters(r.authContext, r.client, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Destination filters (ID: %s)", state.ID.ValueString()),
+			err.Error(),
+		)
+
+		return
+	}
+	filterTitles := map[string]string{}
+	for _, filter := range filters {
+		filterTitles[filter.Id] = filter.Title
+	}
+
+	var removedSubscriptions, removedFilters []string
+	state.Subscriptions, removedSubscriptions = refreshCopiedDestinationChildren(state.Subscriptions, subscriptionNames)
+	state.Filters, removedFilters = refreshCopiedDestinationChildren(state.Filters, filterTitles)
+	if removed := append(removedSubscriptions, removedFilters...); len(removed) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Copied Destination items were removed",
+			fmt.Sprintf("The following copies in Destination (ID: %s) were removed outside of Terraform and are no longer tracked: %s. Replace the resource to copy them again.", state.ID.ValueString(), strings.Join(removed, ", ")),
+		)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *destinationCloneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan models.DestinationClonePlan
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state models.DestinationCloneState
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var settings map[string]interface{}
+	if !plan.Settings.IsNull() {
+		diags = plan.Settings.Unmarshal(&settings)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	out, body, err := r.client.DestinationsAPI.UpdateDestination(r.authContext, state.ID.ValueString()).UpdateDestinationV1Input(api.UpdateDestinationV1Input{
+		Name:     *api.NewNullableString(plan.Name.ValueStringPointer()),
+		Enabled:  plan.Enabled.ValueBoolPointer(),
+		Settings: settings,
+	}).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to update Destination (ID: %s)", state.ID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+
+	state.Name = types.StringPointerValue(out.Data.Destination.Name)
+	state.Enabled = types.BoolValue(out.Data.Destination.Enabled)
+
+	// This is to satisfy terraform requirements that the returned fields must match the input ones because new settings can be generated in the response
+	state.Settings = plan.Settings
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *destinationCloneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state models.DestinationCloneState
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The subscriptions and filters of the Destination are deleted along with it
+	_, body, err := r.client.DestinationsAPI.DeleteDestination(r.authContext, state.ID.ValueString()).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to delete Destination (ID: %s)", state.ID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+}
+
+func (r *destinationCloneResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected ClientInfo, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = config.client
+	r.authContext = config.authContext
+}
+
+// withoutMaskedSettings returns a copy of the settings without the ones masked by the API, along with their sorted keys.
+// refreshCopiedDestinationChildren returns the copies that still exist with their current names, keyed by id in `names`,
+// along with the copies that were removed.
+func refreshCopiedDestinationChildren(copies []models.CopiedDestinationChild, names map[string]string) ([]models.CopiedDestinationChild, []string) {
+	refreshed := []models.CopiedDestinationChild{}
+	removed := []string{}
+	for _, copied := range copies {
+		name, ok := names[copied.ID.ValueString()]
+		if !ok {
+			removed = append(removed, fmt.Sprintf("%q (ID: %s)", copied.Name.ValueString(), copied.ID.ValueString()))
+
+			continue
+		}
+
+		copied.Name = types.StringValue(name)
+		refreshed = append(refreshed, copied)
+	}
+
+	return refreshed, removed
+}
+
+func withoutMaskedSettings(settings map[string]interface{}) (map[string]interface{}, []string) {
+	copied := map[string]interface{}{}
+	masked := []string{}
+
+	for key, value := range settings {
+		if strValue, ok := value.(string); ok && strings.Contains(strValue, "•") {
+			masked = append(masked, key)
+
+			continue
+		}
+		copied[key] = value
+	}
+	slices.Sort(masked)
+
+	return copied, masked
+}
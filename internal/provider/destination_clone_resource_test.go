@@ -0,0 +1,308 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/segmentio/public-api-sdk-go/api"
+)
+
+func TestAccDestinationCloneResource(t *testing.T) {
+	t.Parallel()
+	destinationName := "My template destination"
+	destinationEnabled := "true"
+	copiedSettings := ""
+	copiedSubscriptionSettings := ""
+	copiedSubscriptionDeleted := false
+	copiedFilterTitle := "My filter"
+
+	destinationPayload := func(id string, sourceID string, settings string) string {
+		return `
+			{
+				"data": {
+					"destination": {
+						"id": "` + id + `",
+						"name": "` + destinationName + `",
+						"enabled": ` + destinationEnabled + `,
+						"sourceId": "` + sourceID + `",
+						"settings": ` + settings + `,
+						"metadata": {
+							"id": "my-destination-metadata-id",
+							"name": "Destination Metadata",
+							"description": "Description.",
+							"slug": "destination-metadata",
+							"logos": {
+								"default": "default"
+							},
+							"options": [],
+							"status": "PUBLIC",
+							"categories": [],
+							"website": "https://test.com",
+							"components": [],
+							"previousNames": [],
+							"supportedMethods": {},
+							"supportedPlatforms": {},
+							"supportedFeatures": {},
+							"actions": [],
+							"presets": [],
+							"contacts": [],
+							"supportedRegions": [],
+							"regionEndpoints": []
+						}
+					}
+				}
+			}
+		`
+	}
+
+	fakeServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("content-type", "application/json")
+
+			payload := ""
+			if req.URL.Path == "/destinations/my-template-id" && req.Method == http.MethodGet {
+				payload = destinationPayload("my-template-id", "my-template-source-id", `{"apiKey": "••••••1234", "region": "US"}`)
+			} else if req.URL.Path == "/destinations/my-template-id/subscriptions" {
+				payload = `
+					{
+						"data": {
+							"subscriptions": [
+								{
+									"id": "my-template-subscription-id",
+									"name": "My subscription",
+									"actionId": "my-action-id",
+									"actionSlug": "my-action-slug",
+									"destinationId": "my-template-id",
+									"enabled": true,
+									"settings": {
+										"apiKey": "••••••5678",
+										"url": "https://example.com"
+									},
+									"trigger": "type = \"track\""
+								}
+							],
+							"pagination": {
+								"current": "MA=="
+							}
+						}
+					}
+				`
+			} else if req.URL.Path == "/destination/my-template-id/filters" {
+				payload = `
+					{
+						"data": {
+							"filters": [
+								{
+									"id": "my-template-filter-id",
+									"sourceId": "my-template-source-id",
+									"destinationId": "my-template-id",
+									"if": "type = \"identify\"",
+									"actions": [
+										{
+											"type": "DROP"
+										}
+									],
+									"title": "My filter",
+									"enabled": true,
+									"createdAt": "2024-01-01T00:00:00Z",
+									"updatedAt": "2024-01-01T00:00:00Z"
+								}
+							],
+							"pagination": {
+								"current": "MA=="
+							}
+						}
+					}
+				`
+			} else if req.URL.Path == "/destinations" && req.Method == http.MethodPost {
+				copiedSettings = `{"apiKey": "my-api-key", "region": "US"}`
+				payload = destinationPayload("my-destination-id", "my-source-id", copiedSettings)
+			} else if req.URL.Path == "/destinations/my-destination-id/subscriptions" && req.Method == http.MethodPost {
+				var input api.CreateDestinationSubscriptionAlphaInput
+				_ = json.NewDecoder(req.Body).Decode(&input)
+				settings, _ := json.Marshal(input.Settings)
+				copiedSubscriptionSettings = string(settings)
+
+				payload = `
+					{
+						"data": {
+							"destinationSubscription": {
+								"id": "my-subscription-id",
+								"name": "My subscription",
+								"actionId": "my-action-id",
+								"actionSlug": "my-action-slug",
+								"destinationId": "my-destination-id",
+								"enabled": true,
+								"settings": {
+									"url": "https://example.com"
+								},
+								"trigger": "type = \"track\""
+							}
+						}
+					}
+				`
+			} else if req.URL.Path == "/destinations/my-destination-id/subscriptions" {
+				subscriptions := `
+					{
+						"id": "my-subscription-id",
+						"name": "My subscription",
+						"actionId": "my-action-id",
+						"actionSlug": "my-action-slug",
+						"destinationId": "my-destination-id",
+						"enabled": true,
+						"settings": {
+							"url": "https://example.com"
+						},
+						"trigger": "type = \"track\""
+					}
+				`
+				if copiedSubscriptionDeleted {
+					subscriptions = ""
+				}
+				payload = `{"data": {"subscriptions": [` + subscriptions + `], "pagination": {"current": "MA=="}}}`
+			} else if req.URL.Path == "/destination/my-destination-id/filters" {
+				filter := `
+					{
+						"id": "my-filter-id",
+						"sourceId": "my-source-id",
+						"destinationId": "my-destination-id",
+						"if": "type = \"identify\"",
+						"actions": [
+							{
+								"type": "DROP"
+							}
+						],
+						"title": "` + copiedFilterTitle + `",
+						"enabled": true,
+						"createdAt": "2024-01-01T00:00:00Z",
+						"updatedAt": "2024-01-01T00:00:00Z"
+					}
+				`
+				if req.Method == http.MethodPost {
+					payload = `{"data": {"filter": ` + filter + `}}`
+				} else {
+					payload = `{"data": {"filters": [` + filter + `], "pagination": {"current": "MA=="}}}`
+				}
+			} else if req.URL.Path == "/destinations/my-destination-id" {
+				if req.Method == http.MethodPatch {
+					destinationName = "My cloned destination"
+					destinationEnabled = "false"
+				}
+				payload = destinationPayload("my-destination-id", "my-source-id", copiedSettings)
+			}
+
+			_, _ = w.Write([]byte(payload))
+		}),
+	)
+	defer fakeServer.Close()
+
+	providerConfig := `
+		provider "segment" {
+			url   = "` + fakeServer.URL + `"
+			token = "abc123"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+					resource "segment_destination_clone" "test" {
+						copy_from_destination_id = "my-template-id"
+						source_id                = "my-source-id"
+						enabled                  = true
+						settings = jsonencode({
+							"apiKey" : "my-api-key"
+						})
+						subscription_settings = {
+							"My subscription" = jsonencode({
+								"apiKey" : "my-subscription-api-key"
+							})
+						}
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_destination_clone.test", "id", "my-destination-id"),
+					resource.TestCheckResourceAttr("segment_destination_clone.test", "name", "My template destination"),
+					resource.TestCheckResourceAttr("segment_destination_clone.test", "metadata_id", "my-destination-metadata-id"),
+					resource.TestCheckResourceAttr("segment_destination_clone.test", "settings", "{\"apiKey\":\"my-api-key\"}"),
+					resource.TestCheckResourceAttr("segment_destination_clone.test", "masked_settings.#", "0"),
+					resource.TestCheckResourceAttr("segment_destination_clone.test", "subscriptions.#", "1"),
+					resource.TestCheckResourceAttr("segment_destination_clone.test", "subscriptions.0.id", "my-subscription-id"),
+					resource.TestCheckResourceAttr("segment_destination_clone.test", "subscriptions.0.copied_from_id", "my-template-subscription-id"),
+					resource.TestCheckResourceAttr("segment_destination_clone.test", "filters.#", "1"),
+					resource.TestCheckResourceAttr("segment_destination_clone.test", "filters.0.id", "my-filter-id"),
+					resource.TestCheckResourceAttr("segment_destination_clone.test", "filters.0.name", "My filter"),
+					resource.TestCheckResourceAttr("segment_destination_clone.test", "subscription_settings.My subscription", "{\"apiKey\":\"my-subscription-api-key\"}"),
+					func(_ *terraform.State) error {
+						if expected := `{"apiKey":"my-subscription-api-key","url":"https://example.com"}`; copiedSubscriptionSettings != expected {
+							return fmt.Errorf("expected the copied subscription settings to be %s, got %s", expected, copiedSubscriptionSettings)
+						}
+
+						return nil
+					},
+				),
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+					resource "segment_destination_clone" "test" {
+						copy_from_destination_id = "my-template-id"
+						source_id                = "my-source-id"
+						name                     = "My cloned destination"
+						enabled                  = false
+						settings = jsonencode({
+							"apiKey" : "my-api-key"
+						})
+						subscription_settings = {
+							"My subscription" = jsonencode({
+								"apiKey" : "my-subscription-api-key"
+							})
+						}
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_destination_clone.test", "id", "my-destination-id"),
+					resource.TestCheckResourceAttr("segment_destination_clone.test", "name", "My cloned destination"),
+					resource.TestCheckResourceAttr("segment_destination_clone.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("segment_destination_clone.test", "subscriptions.#", "1"),
+				),
+			},
+			// Read testing after the copies were changed outside of Terraform
+			{
+				PreConfig: func() {
+					copiedSubscriptionDeleted = true
+					copiedFilterTitle = "My renamed filter"
+				},
+				Config: providerConfig + `
+					resource "segment_destination_clone" "test" {
+						copy_from_destination_id = "my-template-id"
+						source_id                = "my-source-id"
+						name                     = "My cloned destination"
+						enabled                  = false
+						settings = jsonencode({
+							"apiKey" : "my-api-key"
+						})
+						subscription_settings = {
+							"My subscription" = jsonencode({
+								"apiKey" : "my-subscription-api-key"
+							})
+						}
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_destination_clone.test", "subscriptions.#", "0"),
+					resource.TestCheckResourceAttr("segment_destination_clone.test", "filters.#", "1"),
+					resource.TestCheckResourceAttr("segment_destination_clone.test", "filters.0.name", "My renamed filter"),
+				),
+			},
+		},
+	})
+}
@@ -52,6 +52,11 @@ func (d *destinationDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 				Computed:    true,
 				Attributes:  destinationMetadataSchema(),
 			},
+			"include_catalog_metadata": schema.BoolAttribute{
+				Description: "Whether the full catalog metadata of the Destination is read into `metadata`. When false, only `metadata.id`, `metadata.name` and `metadata.slug` are read. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+			},
 			"source_id": schema.StringAttribute{
 				Description: "The id of a Source connected to this instance of a Destination. Config API note: analogous to `parent`.",
 				Computed:    true,
@@ -66,7 +71,7 @@ func (d *destinationDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 }
 
 func (d *destinationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var state models.DestinationState
+	var state models.DestinationDataSourceState
 
 	diags := req.Config.Get(ctx, &state)
 
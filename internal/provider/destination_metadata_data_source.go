@@ -10,8 +10,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/segmentio/public-api-sdk-go/api"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -29,6 +32,7 @@ func NewDestinationMetadataDataSource() datasource.DataSource {
 type destinationMetadataDataSource struct {
 	client      *api.APIClient
 	authContext context.Context
+	catalog     *catalogCache
 }
 
 func destinationMetadataSchema() map[string]schema.Attribute {
@@ -378,9 +382,24 @@ func (d *destinationMetadataDataSource) Metadata(_ context.Context, req datasour
 
 // Schema defines the schema for the data source.
 func (d *destinationMetadataDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := destinationMetadataSchema()
+	attributes["id"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The id of the Destination metadata. Config API note: analogous to `name`. Exactly one of `id` or `slug` must be set.",
+		Validators: []validator.String{
+			stringvalidator.ExactlyOneOf(path.MatchRoot("slug")),
+		},
+	}
+	attributes["slug"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The slug used to identify the Destination in the Segment app. Can be set instead of `id` to look up the Destination metadata in the Segment catalog.",
+	}
+
 	resp.Schema = schema.Schema{
 		Description: "Reads a Destination metadata. For more information, visit the [Segment docs](https://segment.com/docs/connections/destinations/catalog/).",
-		Attributes:  destinationMetadataSchema(),
+		Attributes:  attributes,
 	}
 }
 
@@ -395,6 +414,20 @@ func (d *destinationMetadataDataSource) Read(ctx context.Context, req datasource
 		return
 	}
 
+	if state.ID.IsNull() {
+		id, err := findDestinationMetadataID(d.authContext, d.client, d.catalog, state.Slug.ValueString(), "")
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("slug"),
+				"Unable to find Destination metadata",
+				err.Error(),
+			)
+
+			return
+		}
+		state.ID = types.StringValue(id)
+	}
+
 	response, body, err := d.client.CatalogAPI.GetDestinationMetadata(d.authContext, state.ID.ValueString()).Execute()
 	if body != nil {
 		defer body.Body.Close()
@@ -444,4 +477,5 @@ func (d *destinationMetadataDataSource) Configure(_ context.Context, req datasou
 
 	d.client = clientInfo.client
 	d.authContext = clientInfo.authContext
+	d.catalog = clientInfo.catalog
 }
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+
+	"github.com/segmentio/public-api-sdk-go/api"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
+)
+
+var (
+	_ datasource.DataSource              = &destinationPresetsDataSource{}
+	_ datasource.DataSourceWithConfigure = &destinationPresetsDataSource{}
+)
+
+func NewDestinationPresetsDataSource() datasource.DataSource {
+	return &destinationPresetsDataSource{}
+}
+
+type destinationPresetsDataSource struct {
+	client      *api.APIClient
+	authContext context.Context
+}
+
+func (d *destinationPresetsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_destination_presets"
+}
+
+func (d *destinationPresetsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the preset subscriptions recommended by the catalog for an Actions Destination, ready to be used as the configuration of `segment_destination_subscription` resources. For more information, visit the [Segment docs](https://segment.com/docs/connections/destinations/actions/).",
+		Attributes: map[string]schema.Attribute{
+			"metadata_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The id of the Destination metadata, such as `segment_destination.example.metadata.id`.",
+			},
+			"subscriptions": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The preset subscriptions of the Destination metadata.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the subscription.",
+						},
+						"action_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier for the Destination action to trigger.",
+						},
+						"action_slug": schema.StringAttribute{
+							Computed:    true,
+							Description: "The URL-friendly key for the Destination action to trigger.",
+						},
+						"trigger": schema.StringAttribute{
+							Computed:    true,
+							Description: "FQL string that describes what events should trigger the Destination action.",
+						},
+						"settings": schema.StringAttribute{
+							Computed:    true,
+							Description: "The field mappings of the subscription.",
+							CustomType:  jsontypes.NormalizedType{},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *destinationPresetsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state models.DestinationPresetsState
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, body, err := d.client.CatalogAPI.GetDestinationMetadata(d.authContext, state.MetadataID.ValueString()).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Destination metadata (ID: %s)", state.MetadataID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+
+	err = state.Fill(response.Data.DestinationMetadata)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Destination metadata (ID: %s)", state.MetadataID.ValueString()),
+			err.Error(),
+		)
+
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (d *destinationPresetsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientInfo, ok := req.ProviderData.(*ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ClientInfo, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = clientInfo.client
+	d.authContext = clientInfo.authContext
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDestinationPresetsDataSource(t *testing.T) {
+	t.Parallel()
+
+	fakeServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("content-type", "application/json")
+			_, _ = w.Write([]byte(`
+				{
+					"data": {
+						"destinationMetadata": {
+							"id": "destination-metadata-id",
+							"name": "Destination Metadata",
+							"description": "Description.",
+							"slug": "destination-metadata",
+							"logos": {
+								"default": "default"
+							},
+							"options": [],
+							"status": "PUBLIC",
+							"categories": ["Analytics"],
+							"website": "https://test.com",
+							"components": [],
+							"previousNames": [],
+							"supportedMethods": {},
+							"supportedPlatforms": {},
+							"supportedFeatures": {},
+							"actions": [
+								{
+									"id": "action-id",
+									"slug": "send-event",
+									"name": "Send Event",
+									"description": "Send an event.",
+									"platform": "CLOUD",
+									"hidden": false,
+									"defaultTrigger": "type = \"track\"",
+									"fields": []
+								}
+							],
+							"presets": [
+								{
+									"actionId": "action-id",
+									"name": "Track Calls",
+									"trigger": "type = \"track\"",
+									"fields": {
+										"event": {
+											"@path": "$.event"
+										}
+									}
+								}
+							],
+							"contacts": [],
+							"supportedRegions": [],
+							"regionEndpoints": []
+						}
+					}
+				}
+			`))
+		}),
+	)
+	defer fakeServer.Close()
+
+	providerConfig := `
+		provider "segment" {
+			url   = "` + fakeServer.URL + `"
+			token = "abc123"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `data "segment_destination_presets" "test" { metadata_id = "destination-metadata-id" }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.segment_destination_presets.test", "metadata_id", "destination-metadata-id"),
+					resource.TestCheckResourceAttr("data.segment_destination_presets.test", "subscriptions.#", "1"),
+					resource.TestCheckResourceAttr("data.segment_destination_presets.test", "subscriptions.0.name", "Track Calls"),
+					resource.TestCheckResourceAttr("data.segment_destination_presets.test", "subscriptions.0.action_id", "action-id"),
+					resource.TestCheckResourceAttr("data.segment_destination_presets.test", "subscriptions.0.action_slug", "send-event"),
+					resource.TestCheckResourceAttr("data.segment_destination_presets.test", "subscriptions.0.trigger", "type = \"track\""),
+					resource.TestCheckResourceAttr("data.segment_destination_presets.test", "subscriptions.0.settings", "{\"event\":{\"@path\":\"$.event\"}}"),
+				),
+			},
+		},
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -13,11 +14,15 @@ import (
 	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/segmentio/public-api-sdk-go/api"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -26,9 +31,11 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &destinationResource{}
-	_ resource.ResourceWithConfigure   = &destinationResource{}
-	_ resource.ResourceWithImportState = &destinationResource{}
+	_ resource.Resource                 = &destinationResource{}
+	_ resource.ResourceWithConfigure    = &destinationResource{}
+	_ resource.ResourceWithImportState  = &destinationResource{}
+	_ resource.ResourceWithModifyPlan   = &destinationResource{}
+	_ resource.ResourceWithUpgradeState = &destinationResource{}
 )
 
 // NewDestinationResource is a helper function to simplify the provider implementation.
@@ -40,6 +47,7 @@ func NewDestinationResource() resource.Resource {
 type destinationResource struct {
 	client      *api.APIClient
 	authContext context.Context
+	catalog     *catalogCache
 }
 
 // Metadata returns the resource type name.
@@ -50,6 +58,7 @@ func (r *destinationResource) Metadata(_ context.Context, req resource.MetadataR
 // Schema defines the schema for the resource.
 func (r *destinationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Description: "Configures a Destination. For more information, visit the [Segment docs](https://segment.com/docs/connections/destinations/).\n\n" +
 			docs.GenerateImportDocs("<id>", "segment_destination"),
 		Attributes: map[string]schema.Attribute{
@@ -74,10 +83,28 @@ func (r *destinationResource) Schema(_ context.Context, _ resource.SchemaRequest
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"function_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The id of a Destination Function to create this Destination from, for example `segment_function.example.id`. Exactly one of `function_id` or `metadata` must be set. The Destination metadata is resolved from the catalog id of the Function, and `settings` is validated against the settings declared by the Function. The Destination is replaced when the catalog id changes.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("metadata")),
+				},
+			},
 			"metadata": schema.SingleNestedAttribute{
-				Required:   true,
+				Description: "The metadata of the Destination. Exactly one of `metadata` or `function_id` must be set.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
 				Attributes: destinationMetadataResourceSchema(),
 			},
+			"include_catalog_metadata": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the full catalog metadata of the Destination is stored in `metadata`. When false, only `metadata.id`, `metadata.name` and `metadata.slug` are stored, which keeps the state small. The full catalog metadata remains available from the `segment_destination_metadata` data source. Defaults to true.",
+			},
 			"settings": schema.StringAttribute{
 				Required:    true,
 				Description: "The settings associated with the Destination. Only settings included in the configuration will be managed by Terraform.",
@@ -90,14 +117,16 @@ func (r *destinationResource) Schema(_ context.Context, _ resource.SchemaRequest
 func destinationMetadataResourceSchema() map[string]schema.Attribute {
 	return map[string]schema.Attribute{
 		"id": schema.StringAttribute{
-			Description: "The id of the Destination metadata. Config API note: analogous to `name`.",
-			Required:    true,
-			PlanModifiers: []planmodifier.String{
-				stringplanmodifier.RequiresReplace(),
+			Description: "The id of the Destination metadata. Config API note: analogous to `name`. Exactly one of `id`, `slug` or `name` must be set. The Destination is only replaced when the resolved id changes.",
+			Optional:    true,
+			Computed:    true,
+			Validators: []validator.String{
+				stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("slug"), path.MatchRelative().AtParent().AtName("name")),
 			},
 		},
 		"name": schema.StringAttribute{
-			Description: "The user-friendly name of the Destination. Config API note: equal to `displayName`.",
+			Description: "The user-friendly name of the Destination. Can be set instead of `id` to look up the Destination metadata in the Segment catalog. Config API note: equal to `displayName`.",
+			Optional:    true,
 			Computed:    true,
 			PlanModifiers: []planmodifier.String{
 				stringplanmodifier.UseStateForUnknown(),
@@ -111,7 +140,8 @@ func destinationMetadataResourceSchema() map[string]schema.Attribute {
 			},
 		},
 		"slug": schema.StringAttribute{
-			Description: "The slug used to identify the Destination in the Segment app.",
+			Description: "The slug used to identify the Destination in the Segment app. Can be set instead of `id` to look up the Destination metadata in the Segment catalog.",
+			Optional:    true,
 			Computed:    true,
 			PlanModifiers: []planmodifier.String{
 				stringplanmodifier.UseStateForUnknown(),
@@ -491,6 +521,388 @@ func destinationMetadataResourceSchema() map[string]schema.Attribute {
 	}
 }
 
+// ModifyPlan resolves the metadata id from the catalog or from the Function, and marks the catalog metadata as unknown
+// when include_catalog_metadata changes so that it is refreshed on apply.
+func (r *destinationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planMetadataID(ctx, req, resp, func(slug string, name string) (string, error) {
+		return findDestinationMetadataID(r.authContext, r.client, r.catalog, slug, name)
+	})
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planFunctionMetadata(ctx, req, resp, r.authContext, r.client, "DESTINATION")
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var planIncludeCatalogMetadata, stateIncludeCatalogMetadata types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("include_catalog_metadata"), &planIncludeCatalogMetadata)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("include_catalog_metadata"), &stateIncludeCatalogMetadata)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if planIncludeCatalogMetadata.Equal(stateIncludeCatalogMetadata) {
+		return
+	}
+
+	// The catalog metadata copied from the state is added or removed on apply, so it is not known yet
+	for name, attribute := range destinationMetadataResourceSchema() {
+		if !attribute.IsComputed() || attribute.IsOptional() {
+			continue
+		}
+
+		attributePath := path.Root("metadata").AtName(name)
+		attributeType := attribute.GetType()
+		unknown, err := attributeType.ValueFromTerraform(ctx, tftypes.NewValue(attributeType.TerraformType(ctx), tftypes.UnknownValue))
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(attributePath, "Unable to plan Destination metadata", err.Error())
+
+			return
+		}
+
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, attributePath, unknown)...)
+	}
+}
+
+// destinationSchemaV0 is the schema of segment_destination before include_catalog_metadata and function_id were added.
+// It must not change, since it decodes the state written by previous versions of the provider.
+func destinationSchemaV0() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"name": schema.StringAttribute{
+				Optional: true,
+			},
+			"enabled": schema.BoolAttribute{
+				Required: true,
+			},
+			"source_id": schema.StringAttribute{
+				Required: true,
+			},
+			"metadata": schema.SingleNestedAttribute{
+				Required:   true,
+				Attributes: destinationMetadataResourceSchemaV0(),
+			},
+			"settings": schema.StringAttribute{
+				Required:   true,
+				CustomType: jsontypes.NormalizedType{},
+			},
+		},
+	}
+}
+
+func destinationMetadataResourceSchemaV0() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Required: true,
+		},
+		"name": schema.StringAttribute{
+			Computed: true,
+		},
+		"description": schema.StringAttribute{
+			Computed: true,
+		},
+		"slug": schema.StringAttribute{
+			Computed: true,
+		},
+		"logos": schema.SingleNestedAttribute{
+			Computed: true,
+			Attributes: map[string]schema.Attribute{
+				"default": schema.StringAttribute{
+					Computed: true,
+				},
+				"mark": schema.StringAttribute{
+					Computed: true,
+				},
+				"alt": schema.StringAttribute{
+					Computed: true,
+				},
+			},
+		},
+		"options": schema.ListNestedAttribute{
+			Computed: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Computed: true,
+					},
+					"type": schema.StringAttribute{
+						Computed: true,
+					},
+					"required": schema.BoolAttribute{
+						Computed: true,
+					},
+					"description": schema.StringAttribute{
+						Computed: true,
+					},
+					"default_value": schema.StringAttribute{
+						CustomType: jsontypes.NormalizedType{},
+						Computed:   true,
+					},
+					"label": schema.StringAttribute{
+						Computed: true,
+					},
+				},
+			},
+		},
+		"status": schema.StringAttribute{
+			Computed: true,
+		},
+		"previous_names": schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+		},
+		"categories": schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+		},
+		"website": schema.StringAttribute{
+			Computed: true,
+		},
+		"components": schema.ListNestedAttribute{
+			Computed: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Computed: true,
+					},
+					"code": schema.StringAttribute{
+						Computed: true,
+					},
+					"owner": schema.StringAttribute{
+						Computed: true,
+					},
+				},
+			},
+		},
+		"supported_features": schema.SingleNestedAttribute{
+			Computed: true,
+			Attributes: map[string]schema.Attribute{
+				"cloud_mode_instances": schema.StringAttribute{
+					Computed: true,
+				},
+				"device_mode_instances": schema.StringAttribute{
+					Computed: true,
+				},
+				"replay": schema.BoolAttribute{
+					Computed: true,
+				},
+				"browser_unbundling": schema.BoolAttribute{
+					Computed: true,
+				},
+				"browser_unbundling_public": schema.BoolAttribute{
+					Computed: true,
+				},
+			},
+		},
+		"supported_methods": schema.SingleNestedAttribute{
+			Computed: true,
+			Attributes: map[string]schema.Attribute{
+				"pageview": schema.BoolAttribute{
+					Computed: true,
+				},
+				"identify": schema.BoolAttribute{
+					Computed: true,
+				},
+				"alias": schema.BoolAttribute{
+					Computed: true,
+				},
+				"track": schema.BoolAttribute{
+					Computed: true,
+				},
+				"group": schema.BoolAttribute{
+					Computed: true,
+				},
+			},
+		},
+		"supported_platforms": schema.SingleNestedAttribute{
+			Computed: true,
+			Attributes: map[string]schema.Attribute{
+				"browser": schema.BoolAttribute{
+					Computed: true,
+				},
+				"server": schema.BoolAttribute{
+					Computed: true,
+				},
+				"mobile": schema.BoolAttribute{
+					Computed: true,
+				},
+			},
+		},
+		"actions": schema.ListNestedAttribute{
+			Computed: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed: true,
+					},
+					"slug": schema.StringAttribute{
+						Computed: true,
+					},
+					"name": schema.StringAttribute{
+						Computed: true,
+					},
+					"description": schema.StringAttribute{
+						Computed: true,
+					},
+					"platform": schema.StringAttribute{
+						Computed: true,
+					},
+					"hidden": schema.BoolAttribute{
+						Computed: true,
+					},
+					"default_trigger": schema.StringAttribute{
+						Computed: true,
+					},
+					"fields": schema.ListNestedAttribute{
+						Computed: true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"id": schema.StringAttribute{
+									Computed: true,
+								},
+								"sort_order": schema.Float64Attribute{
+									Computed: true,
+								},
+								"field_key": schema.StringAttribute{
+									Computed: true,
+								},
+								"label": schema.StringAttribute{
+									Computed: true,
+								},
+								"type": schema.StringAttribute{
+									Computed: true,
+								},
+								"description": schema.StringAttribute{
+									Computed: true,
+								},
+								"placeholder": schema.StringAttribute{
+									Computed: true,
+								},
+								"default_value": schema.StringAttribute{
+									CustomType: jsontypes.NormalizedType{},
+									Computed:   true,
+								},
+								"required": schema.BoolAttribute{
+									Computed: true,
+								},
+								"multiple": schema.BoolAttribute{
+									Computed: true,
+								},
+								"choices": schema.StringAttribute{
+									CustomType: jsontypes.NormalizedType{},
+									Computed:   true,
+								},
+								"dynamic": schema.BoolAttribute{
+									Computed: true,
+								},
+								"allow_null": schema.BoolAttribute{
+									Computed: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"presets": schema.ListNestedAttribute{
+			Computed: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"action_id": schema.StringAttribute{
+						Computed: true,
+					},
+					"name": schema.StringAttribute{
+						Computed: true,
+					},
+					"fields": schema.StringAttribute{
+						CustomType: jsontypes.NormalizedType{},
+						Computed:   true,
+					},
+					"trigger": schema.StringAttribute{
+						Computed: true,
+					},
+				},
+			},
+		},
+		"contacts": schema.ListNestedAttribute{
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Computed: true,
+					},
+					"email": schema.StringAttribute{
+						Computed: true,
+					},
+					"role": schema.StringAttribute{
+						Computed: true,
+					},
+					"is_primary": schema.BoolAttribute{
+						Computed: true,
+					},
+				},
+			},
+			Computed: true,
+			Optional: true,
+		},
+		"partner_owned": schema.BoolAttribute{
+			Computed: true,
+			Optional: true,
+		},
+		"supported_regions": schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Optional:    true,
+		},
+		"region_endpoints": schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Optional:    true,
+		},
+	}
+}
+
+// UpgradeState upgrades the state of Destinations created with previous versions of the provider.
+func (r *destinationResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	priorSchema := destinationSchemaV0()
+
+	return map[int64]resource.StateUpgrader{
+		// Version 0 always stored the full catalog metadata
+		0: {
+			PriorSchema: &priorSchema,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var priorState models.DestinationStateV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				var metadata models.DestinationMetadataState
+				resp.Diagnostics.Append(priorState.Metadata.As(ctx, &metadata, basetypes.ObjectAsOptions{})...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				state := models.DestinationState{
+					ID:                     priorState.ID,
+					Name:                   priorState.Name,
+					Enabled:                priorState.Enabled,
+					Metadata:               &metadata,
+					IncludeCatalogMetadata: types.BoolValue(true),
+					SourceID:               priorState.SourceID,
+					Settings:               priorState.Settings,
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+			},
+		},
+	}
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *destinationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan
@@ -553,6 +965,8 @@ func (r *destinationResource) Create(ctx context.Context, req resource.CreateReq
 	resp.State.SetAttribute(ctx, path.Root("id"), out.Data.Destination.Id)
 
 	var state models.DestinationState
+	state.IncludeCatalogMetadata = plan.IncludeCatalogMetadata
+	state.FunctionID = plan.FunctionID
 	err = state.Fill(&out.Data.Destination)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -605,6 +1019,8 @@ func (r *destinationResource) Read(ctx context.Context, req resource.ReadRequest
 	destination := out.Data.Destination
 
 	var state models.DestinationState
+	state.IncludeCatalogMetadata = previousState.IncludeCatalogMetadata
+	state.FunctionID = previousState.FunctionID
 	err = state.Fill(&destination)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -674,6 +1090,8 @@ func (r *destinationResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 
 	var state models.DestinationState
+	state.IncludeCatalogMetadata = plan.IncludeCatalogMetadata
+	state.FunctionID = plan.FunctionID
 	err = state.Fill(&out.Data.Destination)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -743,4 +1161,5 @@ func (r *destinationResource) Configure(_ context.Context, req resource.Configur
 
 	r.client = config.client
 	r.authContext = config.authContext
+	r.catalog = config.catalog
 }
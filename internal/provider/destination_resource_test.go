@@ -1,11 +1,16 @@
 package provider
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/segmentio/public-api-sdk-go/api"
 )
 
 func TestAccDestinationResource(t *testing.T) {
@@ -409,8 +414,215 @@ func TestAccDestinationResource(t *testing.T) {
 					resource.TestCheckResourceAttr("segment_destination.test", "source_id", "my-source-id"),
 					resource.TestCheckResourceAttr("segment_destination.test", "metadata.id", "my-destination-metadata-id"),
 					resource.TestCheckResourceAttr("segment_destination.test", "settings", "{\"myKey\":\"myNewValue\"}"),
+					resource.TestCheckResourceAttr("segment_destination.test", "include_catalog_metadata", "true"),
 				),
 			},
+			// Omit catalog metadata testing
+			{
+				Config: providerConfig + `
+						resource "segment_destination" "test" {
+							enabled = false
+							source_id = "my-source-id"
+							metadata = {
+								id = "my-destination-metadata-id"
+							}
+							include_catalog_metadata = false
+							name = "My destination name"
+							settings = jsonencode({
+								"myKey": "myNewValue"
+							})
+						}
+					`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_destination.test", "include_catalog_metadata", "false"),
+					resource.TestCheckResourceAttr("segment_destination.test", "metadata.id", "my-destination-metadata-id"),
+					resource.TestCheckResourceAttr("segment_destination.test", "metadata.name", "Destination Metadata"),
+					resource.TestCheckResourceAttr("segment_destination.test", "metadata.slug", "destination-metadata"),
+					resource.TestCheckNoResourceAttr("segment_destination.test", "metadata.description"),
+					resource.TestCheckNoResourceAttr("segment_destination.test", "metadata.actions.#"),
+					resource.TestCheckNoResourceAttr("segment_destination.test", "metadata.presets.#"),
+					resource.TestCheckNoResourceAttr("segment_destination.test", "metadata.options.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDestinationResourceFunction(t *testing.T) {
+	t.Parallel()
+
+	created := 0
+	metadataID := ""
+	fakeServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("content-type", "application/json")
+
+			destination := func() string {
+				return `{
+					"data": {
+						"destination": {
+							"id": "my-destination-id-` + strconv.Itoa(created) + `",
+							"enabled": true,
+							"sourceId": "my-source-id",
+							"settings": {
+								"apiKey": "my-api-key"
+							},
+							"metadata": {
+								"id": "` + metadataID + `",
+								"name": "My Function",
+								"description": "Description.",
+								"slug": "` + metadataID + `-slug",
+								"logos": {
+									"default": "default"
+								},
+								"options": [],
+								"status": "PUBLIC",
+								"categories": [],
+								"website": "https://test.com",
+								"components": [],
+								"previousNames": [],
+								"supportedMethods": {},
+								"supportedPlatforms": {},
+								"supportedFeatures": {},
+								"actions": [],
+								"presets": [],
+								"contacts": [],
+								"supportedRegions": [],
+								"regionEndpoints": []
+							}
+						}
+					}
+				}`
+			}
+
+			payload := ""
+			switch {
+			case strings.HasPrefix(req.URL.Path, "/functions/"):
+				// my-function-id-1 and my-function-id-2 are versions of the same catalog item
+				functionID := strings.TrimPrefix(req.URL.Path, "/functions/")
+				catalogID := "my-catalog-id-1"
+				if functionID == "my-function-id-3" {
+					catalogID = "my-catalog-id-3"
+				}
+				payload = `{
+					"data": {
+						"function": {
+							"id": "` + functionID + `",
+							"workspaceId": "my-workspace-id",
+							"displayName": "My Function",
+							"settings": [
+								{
+									"name": "apiKey",
+									"label": "API key",
+									"description": "The API key",
+									"type": "STRING",
+									"required": true,
+									"sensitive": false
+								}
+							],
+							"catalogId": "` + catalogID + `",
+							"resourceType": "DESTINATION"
+						}
+					}
+				}`
+			case req.URL.Path == "/destinations" && req.Method == http.MethodPost:
+				var input api.CreateDestinationV1Input
+				_ = json.NewDecoder(req.Body).Decode(&input)
+				metadataID = input.MetadataId
+				created++
+				payload = destination()
+			case req.Method == http.MethodDelete:
+				payload = `{"data": {"status": "SUCCESS"}}`
+			case req.Method == http.MethodGet || req.Method == http.MethodPatch:
+				payload = destination()
+			}
+
+			_, _ = w.Write([]byte(payload))
+		}),
+	)
+	defer fakeServer.Close()
+
+	providerConfig := `
+		provider "segment" {
+			url   = "` + fakeServer.URL + `"
+			token = "abc123"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create from a Function
+			{
+				Config: providerConfig + `
+					resource "segment_destination" "test" {
+						source_id   = "my-source-id"
+						function_id = "my-function-id-1"
+						enabled     = true
+						settings = jsonencode({
+							"apiKey" : "my-api-key"
+						})
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_destination.test", "id", "my-destination-id-1"),
+					resource.TestCheckResourceAttr("segment_destination.test", "function_id", "my-function-id-1"),
+					resource.TestCheckResourceAttr("segment_destination.test", "metadata.id", "my-catalog-id-1"),
+					resource.TestCheckResourceAttr("segment_destination.test", "metadata.name", "My Function"),
+					resource.TestCheckResourceAttr("segment_destination.test", "settings", "{\"apiKey\":\"my-api-key\"}"),
+				),
+			},
+			// Switching to a Function with the same catalog id does not replace the Destination
+			{
+				Config: providerConfig + `
+					resource "segment_destination" "test" {
+						source_id   = "my-source-id"
+						function_id = "my-function-id-2"
+						enabled     = true
+						settings = jsonencode({
+							"apiKey" : "my-api-key"
+						})
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_destination.test", "id", "my-destination-id-1"),
+					resource.TestCheckResourceAttr("segment_destination.test", "function_id", "my-function-id-2"),
+					resource.TestCheckResourceAttr("segment_destination.test", "metadata.id", "my-catalog-id-1"),
+				),
+			},
+			// Switching to a Function with another catalog id replaces the Destination
+			{
+				Config: providerConfig + `
+					resource "segment_destination" "test" {
+						source_id   = "my-source-id"
+						function_id = "my-function-id-3"
+						enabled     = true
+						settings = jsonencode({
+							"apiKey" : "my-api-key"
+						})
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_destination.test", "id", "my-destination-id-2"),
+					resource.TestCheckResourceAttr("segment_destination.test", "function_id", "my-function-id-3"),
+					resource.TestCheckResourceAttr("segment_destination.test", "metadata.id", "my-catalog-id-3"),
+				),
+			},
+			// Settings are validated against the settings declared by the Function at plan time
+			{
+				Config: providerConfig + `
+					resource "segment_destination" "test" {
+						source_id   = "my-source-id"
+						function_id = "my-function-id-3"
+						enabled     = true
+						settings = jsonencode({
+							"apiKey" : "my-api-key",
+							"region" : "US"
+						})
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Setting "region" is not declared by the Function`),
+			},
 		},
 	})
 }
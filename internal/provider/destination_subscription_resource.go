@@ -2,30 +2,29 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/segmentio/terraform-provider-segment/internal/provider/docs"
 	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 
 	"github.com/segmentio/public-api-sdk-go/api"
 )
 
 var (
-	_ resource.Resource                = &destinationSubscriptionResource{}
-	_ resource.ResourceWithConfigure   = &destinationSubscriptionResource{}
-	_ resource.ResourceWithImportState = &destinationSubscriptionResource{}
+	_ resource.Resource                 = &destinationSubscriptionResource{}
+	_ resource.ResourceWithConfigure    = &destinationSubscriptionResource{}
+	_ resource.ResourceWithImportState  = &destinationSubscriptionResource{}
+	_ resource.ResourceWithUpgradeState = &destinationSubscriptionResource{}
 )
 
 func NewDestinationSubscriptionResource() resource.Resource {
@@ -43,6 +42,7 @@ func (r *destinationSubscriptionResource) Metadata(_ context.Context, req resour
 
 func (r *destinationSubscriptionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Description: "Configures a Destination subscription to an action. For more information, visit the [Segment docs](https://segment.com/docs/connections/destinations/actions/).\n\n" +
 			docs.GenerateImportDocs("<destination_id>:<subscription_id>", "segment_destination_subscription"),
 		Attributes: map[string]schema.Attribute{
@@ -95,18 +95,54 @@ func (r *destinationSubscriptionResource) Schema(_ context.Context, _ resource.S
 				Description: `The customer settings for action fields. Only settings included in the configuration will be managed by Terraform.`,
 				CustomType:  jsontypes.NormalizedType{},
 			},
+			"reverse_etl_schedule": reverseETLScheduleSchema(),
+		},
+	}
+}
+
+// destinationSubscriptionSchemaV0 is the schema of segment_destination_subscription before the reverse ETL schedule
+// could be configured with typed blocks. It must not change, since it decodes the state written by previous versions
+// of the provider.
+func destinationSubscriptionSchemaV0() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"destination_id": schema.StringAttribute{
+				Required: true,
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"enabled": schema.BoolAttribute{
+				Required: true,
+			},
+			"action_id": schema.StringAttribute{
+				Required: true,
+			},
+			"action_slug": schema.StringAttribute{
+				Computed: true,
+			},
+			"trigger": schema.StringAttribute{
+				Required: true,
+			},
+			"model_id": schema.StringAttribute{
+				Optional: true,
+			},
+			"settings": schema.StringAttribute{
+				Required:   true,
+				CustomType: jsontypes.NormalizedType{},
+			},
 			"reverse_etl_schedule": schema.SingleNestedAttribute{
-				Optional:    true,
-				Description: "(Reverse ETL only) The schedule for the subscription being attached to ReverseETL model.",
+				Optional: true,
 				Attributes: map[string]schema.Attribute{
 					"strategy": schema.StringAttribute{
-						Required:    true,
-						Description: "Strategy supports the following modes: PERIODIC, SPECIFIC_DAYS, CRON, DBT_CLOUD or MANUAL.",
+						Required: true,
 					},
 					"config": schema.StringAttribute{
-						Optional:    true,
-						Description: "Configures the schedule for the subscription.",
-						CustomType:  jsontypes.NormalizedType{},
+						Optional:   true,
+						CustomType: jsontypes.NormalizedType{},
 					},
 				},
 			},
@@ -114,6 +150,61 @@ func (r *destinationSubscriptionResource) Schema(_ context.Context, _ resource.S
 	}
 }
 
+// UpgradeState upgrades the state of subscriptions created with previous versions of the provider.
+func (r *destinationSubscriptionResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	priorSchema := destinationSubscriptionSchemaV0()
+
+	return map[int64]resource.StateUpgrader{
+		// Version 0 only configured the reverse ETL schedule as JSON
+		0: {
+			PriorSchema: &priorSchema,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var priorState models.DestinationSubscriptionStateV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				state := models.DestinationSubscriptionState{
+					ID:            priorState.ID,
+					DestinationID: priorState.DestinationID,
+					Name:          priorState.Name,
+					Enabled:       priorState.Enabled,
+					ActionID:      priorState.ActionID,
+					ActionSlug:    priorState.ActionSlug,
+					Trigger:       priorState.Trigger,
+					ModelID:       priorState.ModelID,
+					Settings:      priorState.Settings,
+				}
+
+				if priorState.ReverseETLSchedule != nil {
+					state.ReverseETLSchedule = &models.ReverseETLScheduleState{
+						Strategy: priorState.ReverseETLSchedule.Strategy,
+						Config:   priorState.ReverseETLSchedule.Config,
+					}
+
+					// The typed config is derived from the JSON one when it can be decoded, otherwise it is set by the next refresh
+					schedule, diags := state.ReverseETLSchedule.ToAPIValue()
+					if !diags.HasError() {
+						err := state.ReverseETLSchedule.Fill(*schedule, time.Now())
+						if err != nil {
+							resp.Diagnostics.AddError(
+								"Unable to upgrade reverse ETL schedule state",
+								err.Error(),
+							)
+
+							return
+						}
+						state.ReverseETLSchedule.Config = priorState.ReverseETLSchedule.Config
+					}
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+			},
+		},
+	}
+}
+
 func (r *destinationSubscriptionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan models.DestinationSubscriptionPlan
 	diags := req.Plan.Get(ctx, &plan)
@@ -254,6 +345,7 @@ func (r *destinationSubscriptionResource) Read(ctx context.Context, req resource
 
 		return
 	}
+	state.ReverseETLSchedule.KeepNextRuns(ctx, previousState.ReverseETLSchedule)
 
 	// Merge settings: keep config-defined settings while ignoring backend-generated ones not in config
 	if !previousState.Settings.IsNull() && !previousState.Settings.IsUnknown() {
@@ -336,6 +428,7 @@ func (r *destinationSubscriptionResource) Update(ctx context.Context, req resour
 		return
 	}
 
+	previousSchedule := state.ReverseETLSchedule
 	err = state.Fill(out.Data.GetSubscription())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -345,6 +438,7 @@ func (r *destinationSubscriptionResource) Update(ctx context.Context, req resour
 
 		return
 	}
+	state.ReverseETLSchedule.KeepNextRuns(ctx, previousSchedule)
 
 	// This is to satisfy terraform requirements that the returned fields must match the input ones because new settings can be generated in the response
 	state.Settings = plan.Settings
@@ -412,167 +506,3 @@ func (r *destinationSubscriptionResource) Configure(_ context.Context, req resou
 	r.client = config.client
 	r.authContext = config.authContext
 }
-
-func getSchedule(ctx context.Context, planSchedule basetypes.ObjectValue) (*api.ReverseEtlScheduleDefinition, diag.Diagnostics) {
-	var reverseETLSchedule *api.ReverseEtlScheduleDefinition
-	var diags diag.Diagnostics
-	if !planSchedule.IsNull() && !planSchedule.IsUnknown() {
-		reverseETLSchedule = &api.ReverseEtlScheduleDefinition{}
-
-		wrappedReverseETLModelScheduleStrategy, err := planSchedule.Attributes()["strategy"].ToTerraformValue(ctx)
-		if err != nil {
-			diags.AddError(
-				"Unable to decode reverse ETL schedule strategy",
-				err.Error(),
-			)
-
-			return nil, diags
-		}
-
-		var reverseETLModelScheduleStrategy string
-		err = wrappedReverseETLModelScheduleStrategy.As(&reverseETLModelScheduleStrategy)
-		if err != nil {
-			diags.AddError(
-				"Unable to decode reverse ETL schedule strategy",
-				err.Error(),
-			)
-
-			return nil, diags
-		}
-
-		reverseETLSchedule.Strategy = reverseETLModelScheduleStrategy
-
-		wrappedReverseETLModelScheduleConfig, err := planSchedule.Attributes()["config"].ToTerraformValue(ctx)
-		if err != nil {
-			diags.AddError(
-				"Unable to decode reverse ETL schedule config",
-				err.Error(),
-			)
-
-			return nil, diags
-		}
-
-		if !wrappedReverseETLModelScheduleConfig.IsNull() && wrappedReverseETLModelScheduleConfig.IsKnown() {
-			if reverseETLSchedule.Strategy == "PERIODIC" {
-				reverseETLModelScheduleConfig := api.ReverseEtlPeriodicScheduleConfig{}
-				var config string
-				err = wrappedReverseETLModelScheduleConfig.As(&config)
-				if err != nil {
-					diags.AddError(
-						"Unable to decode reverse ETL schedule config",
-						err.Error(),
-					)
-
-					return nil, diags
-				}
-
-				err = json.Unmarshal([]byte(config), &reverseETLModelScheduleConfig)
-				if err != nil {
-					diags.AddError(
-						"Unable to decode reverse ETL schedule config",
-						err.Error(),
-					)
-
-					return nil, diags
-				}
-
-				reverseETLSchedule.Config = *api.NewNullableConfig(&api.Config{
-					ReverseEtlPeriodicScheduleConfig: &reverseETLModelScheduleConfig,
-				})
-			} else if reverseETLSchedule.Strategy == "SPECIFIC_DAYS" {
-				reverseETLModelScheduleConfig := api.ReverseEtlSpecificTimeScheduleConfig{}
-				var config string
-				err = wrappedReverseETLModelScheduleConfig.As(&config)
-				if err != nil {
-					diags.AddError(
-						"Unable to decode reverse ETL schedule config",
-						err.Error(),
-					)
-
-					return nil, diags
-				}
-
-				err = json.Unmarshal([]byte(config), &reverseETLModelScheduleConfig)
-				if err != nil {
-					diags.AddError(
-						"Unable to decode reverse ETL schedule config",
-						err.Error(),
-					)
-
-					return nil, diags
-				}
-
-				reverseETLSchedule.Config = *api.NewNullableConfig(&api.Config{
-					ReverseEtlSpecificTimeScheduleConfig: &reverseETLModelScheduleConfig,
-				})
-			} else if reverseETLSchedule.Strategy == "MANUAL" {
-				diags.AddError(
-					"Manual reverse ETL schedule strategy does not require a config",
-					"Manual reverse ETL schedule strategy does not require a config",
-				)
-				reverseETLSchedule.Config = *api.NewNullableConfig(nil)
-			} else if reverseETLSchedule.Strategy == "CRON" {
-				reverseETLModelScheduleConfig := api.ReverseEtlCronScheduleConfig{}
-				var config string
-				err = wrappedReverseETLModelScheduleConfig.As(&config)
-				if err != nil {
-					diags.AddError(
-						"Unable to decode reverse ETL schedule config",
-						err.Error(),
-					)
-
-					return nil, diags
-				}
-
-				err = json.Unmarshal([]byte(config), &reverseETLModelScheduleConfig)
-				if err != nil {
-					diags.AddError(
-						"Unable to decode reverse ETL schedule config",
-						err.Error(),
-					)
-
-					return nil, diags
-				}
-
-				reverseETLSchedule.Config = *api.NewNullableConfig(&api.Config{
-					ReverseEtlCronScheduleConfig: &reverseETLModelScheduleConfig,
-				})
-			} else if reverseETLSchedule.Strategy == "DBT_CLOUD" {
-				reverseETLModelScheduleConfig := api.ReverseEtlDbtCloudScheduleConfig{}
-				var config string
-				err = wrappedReverseETLModelScheduleConfig.As(&config)
-				if err != nil {
-					diags.AddError(
-						"Unable to decode reverse ETL schedule config",
-						err.Error(),
-					)
-
-					return nil, diags
-				}
-
-				err = json.Unmarshal([]byte(config), &reverseETLModelScheduleConfig)
-				if err != nil {
-					diags.AddError(
-						"Unable to decode reverse ETL schedule config",
-						err.Error(),
-					)
-
-					return nil, diags
-				}
-
-				reverseETLSchedule.Config = *api.NewNullableConfig(&api.Config{
-					ReverseEtlDbtCloudScheduleConfig: &reverseETLModelScheduleConfig,
-				})
-			} else {
-				diags.AddError(
-					"Unsupported reverse ETL schedule strategy",
-					fmt.Sprintf("Strategy %q is not supported", reverseETLSchedule.Strategy),
-				)
-
-				return nil, diags
-			}
-		}
-	}
-
-	return reverseETLSchedule, diags
-}
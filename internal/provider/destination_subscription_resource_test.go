@@ -1,11 +1,23 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/segmentio/public-api-sdk-go/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
 )
 
 func TestAccDestinationSubscriptionResource(t *testing.T) {
@@ -378,6 +390,8 @@ func TestAccDestinationSubscriptionResourceWithModel(t *testing.T) {
 					resource.TestCheckResourceAttr("segment_destination_subscription.test", "settings", "{}"),
 					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.strategy", "PERIODIC"),
 					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.config", "{\"interval\":\"1d\"}"),
+					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.periodic.interval", "1d"),
+					resource.TestCheckNoResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.cron"),
 				),
 			},
 			// ImportState testing
@@ -439,3 +453,269 @@ func TestAccDestinationSubscriptionResourceWithModel(t *testing.T) {
 		},
 	})
 }
+
+func TestAccDestinationSubscriptionResourceScheduleBlocks(t *testing.T) {
+	t.Parallel()
+
+	subscription := api.DestinationSubscription{
+		Id:            "my-subscription-id",
+		Name:          "My subscription name",
+		ActionId:      "my-action-id",
+		ActionSlug:    "my-action-slug",
+		DestinationId: "my-destination-id",
+		Enabled:       true,
+		Settings:      map[string]interface{}{},
+		Trigger:       "type = \"track\"",
+		ModelId:       api.PtrString("my-model-id"),
+	}
+
+	fakeServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("content-type", "application/json")
+
+			var payload map[string]interface{}
+			if req.URL.Path == "/destinations/my-destination-id/subscriptions" && req.Method == http.MethodPost {
+				payload = map[string]interface{}{"destinationSubscription": subscription}
+			} else if req.URL.Path == "/destinations/my-destination-id/subscriptions/my-subscription-id" && req.Method == http.MethodPatch {
+				var input api.UpdateSubscriptionForDestinationAlphaInput
+				_ = json.NewDecoder(req.Body).Decode(&input)
+				subscription.ReverseETLSchedule = input.Input.ReverseETLSchedule
+				payload = map[string]interface{}{"subscription": subscription}
+			} else if req.URL.Path == "/destinations/my-destination-id/subscriptions/my-subscription-id" && req.Method == http.MethodGet {
+				payload = map[string]interface{}{"subscription": subscription}
+			} else if req.URL.Path == "/destinations/my-destination-id/subscriptions/my-subscription-id" && req.Method == http.MethodDelete {
+				payload = map[string]interface{}{"status": "SUCCESS"}
+			}
+
+			out, _ := json.Marshal(map[string]interface{}{"data": payload})
+			_, _ = w.Write(out)
+		}),
+	)
+	defer fakeServer.Close()
+
+	providerConfig := `
+		provider "segment" {
+			url   = "` + fakeServer.URL + `"
+			token = "abc123"
+		}
+	`
+
+	subscriptionConfig := func(schedule string) string {
+		return providerConfig + `
+			resource "segment_destination_subscription" "test" {
+				destination_id = "my-destination-id"
+				name = "My subscription name"
+				enabled = true
+				action_id = "my-action-id"
+				trigger = "type = \"track\""
+				settings = jsonencode({})
+				model_id = "my-model-id"
+				reverse_etl_schedule = {
+					` + schedule + `
+				}
+			}
+		`
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with the cron block
+			{
+				Config: subscriptionConfig(`
+					strategy = "CRON"
+					cron = {
+						spec     = "0 */6 * * *"
+						timezone = "America/New_York"
+					}
+				`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.strategy", "CRON"),
+					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.cron.spec", "0 */6 * * *"),
+					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.cron.timezone", "America/New_York"),
+					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.config", "{\"spec\":\"0 */6 * * *\",\"timezone\":\"America/New_York\"}"),
+					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.next_runs.#", "5"),
+					resource.TestCheckNoResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.periodic"),
+					resource.TestCheckNoResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.specific_days"),
+					resource.TestCheckNoResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.dbt_cloud"),
+				),
+			},
+			// Update to the specific_days block
+			{
+				Config: subscriptionConfig(`
+					strategy = "SPECIFIC_DAYS"
+					specific_days = {
+						days     = [1, 3]
+						hours    = [8]
+						timezone = "Europe/Paris"
+					}
+				`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.strategy", "SPECIFIC_DAYS"),
+					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.specific_days.days.#", "2"),
+					resource.TestCheckTypeSetElemAttr("segment_destination_subscription.test", "reverse_etl_schedule.specific_days.days.*", "1"),
+					resource.TestCheckTypeSetElemAttr("segment_destination_subscription.test", "reverse_etl_schedule.specific_days.days.*", "3"),
+					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.specific_days.hours.#", "1"),
+					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.specific_days.timezone", "Europe/Paris"),
+					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.next_runs.#", "5"),
+					resource.TestCheckNoResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.cron"),
+				),
+			},
+			// Update to the dbt_cloud block
+			{
+				Config: subscriptionConfig(`
+					strategy = "DBT_CLOUD"
+					dbt_cloud = {
+						job_id     = "123"
+						account_id = "456"
+					}
+				`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.strategy", "DBT_CLOUD"),
+					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.dbt_cloud.job_id", "123"),
+					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.dbt_cloud.account_id", "456"),
+					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.next_runs.#", "0"),
+					resource.TestCheckNoResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.specific_days"),
+				),
+			},
+			// Switch from the block to config
+			{
+				Config: subscriptionConfig(`
+					strategy = "PERIODIC"
+					config   = jsonencode({ interval = "1d" })
+				`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.strategy", "PERIODIC"),
+					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.config", "{\"interval\":\"1d\"}"),
+					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.periodic.interval", "1d"),
+					resource.TestCheckNoResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.dbt_cloud"),
+				),
+			},
+			// Switch from config back to the block
+			{
+				Config: subscriptionConfig(`
+					strategy = "PERIODIC"
+					periodic = {
+						interval = "6h"
+					}
+				`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.strategy", "PERIODIC"),
+					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.periodic.interval", "6h"),
+					resource.TestCheckResourceAttr("segment_destination_subscription.test", "reverse_etl_schedule.config", "{\"interval\":\"6h\"}"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestDestinationSubscriptionResourceUpgradeState(t *testing.T) {
+	t.Parallel()
+
+	upgrade := func(t *testing.T, strategy string, config jsontypes.Normalized) *models.ReverseETLScheduleState {
+		t.Helper()
+		ctx := context.Background()
+		r := &destinationSubscriptionResource{}
+		upgrader := r.UpgradeState(ctx)[0]
+
+		priorState := tfsdk.State{
+			Schema: *upgrader.PriorSchema,
+			Raw:    tftypes.NewValue(upgrader.PriorSchema.Type().TerraformType(ctx), nil),
+		}
+		diags := priorState.Set(ctx, models.DestinationSubscriptionStateV0{
+			ID:                 types.StringValue("my-subscription-id"),
+			DestinationID:      types.StringValue("my-destination-id"),
+			Name:               types.StringValue("My subscription name"),
+			Enabled:            types.BoolValue(true),
+			ActionID:           types.StringValue("my-action-id"),
+			ActionSlug:         types.StringValue("my-action-slug"),
+			Trigger:            types.StringValue("type = \"track\""),
+			ModelID:            types.StringValue("my-model-id"),
+			Settings:           jsontypes.NewNormalizedValue(`{}`),
+			ReverseETLSchedule: &models.ReverseETLScheduleStateV0{Strategy: types.StringValue(strategy), Config: config},
+		})
+		require.False(t, diags.HasError(), diags)
+
+		var schemaResp fwresource.SchemaResponse
+		r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+		resp := fwresource.UpgradeStateResponse{
+			State: tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			},
+		}
+		upgrader.StateUpgrader(ctx, fwresource.UpgradeStateRequest{State: &priorState}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		var state models.DestinationSubscriptionState
+		diags = resp.State.Get(ctx, &state)
+		require.False(t, diags.HasError(), diags)
+		assert.Equal(t, "my-subscription-id", state.ID.ValueString())
+		assert.Equal(t, "my-model-id", state.ModelID.ValueString())
+		require.NotNil(t, state.ReverseETLSchedule)
+		assert.Equal(t, strategy, state.ReverseETLSchedule.Strategy.ValueString())
+		assert.True(t, state.ReverseETLSchedule.Config.Equal(config))
+
+		return state.ReverseETLSchedule
+	}
+
+	t.Run("periodic", func(t *testing.T) {
+		t.Parallel()
+		schedule := upgrade(t, models.ReverseETLScheduleStrategyPeriodic, jsontypes.NewNormalizedValue(`{"interval":"1d"}`))
+
+		require.NotNil(t, schedule.Periodic)
+		assert.Equal(t, "1d", schedule.Periodic.Interval.ValueString())
+		assert.Nil(t, schedule.NextRuns)
+	})
+
+	t.Run("specific days", func(t *testing.T) {
+		t.Parallel()
+		schedule := upgrade(t, models.ReverseETLScheduleStrategySpecificDays, jsontypes.NewNormalizedValue(`{"days":[1,3],"hours":[8],"timezone":"UTC"}`))
+
+		require.NotNil(t, schedule.SpecificDays)
+		assert.Equal(t, []types.Int64{types.Int64Value(1), types.Int64Value(3)}, schedule.SpecificDays.Days)
+		assert.Equal(t, []types.Int64{types.Int64Value(8)}, schedule.SpecificDays.Hours)
+		assert.Equal(t, "UTC", schedule.SpecificDays.Timezone.ValueString())
+		assert.Len(t, schedule.NextRuns, models.ReverseETLScheduleNextRunsCount)
+	})
+
+	t.Run("cron", func(t *testing.T) {
+		t.Parallel()
+		schedule := upgrade(t, models.ReverseETLScheduleStrategyCron, jsontypes.NewNormalizedValue(`{"spec":"0 */6 * * *","timezone":"America/New_York"}`))
+
+		require.NotNil(t, schedule.Cron)
+		assert.Equal(t, "0 */6 * * *", schedule.Cron.Spec.ValueString())
+		assert.Equal(t, "America/New_York", schedule.Cron.Timezone.ValueString())
+		assert.Len(t, schedule.NextRuns, models.ReverseETLScheduleNextRunsCount)
+	})
+
+	t.Run("dbt cloud", func(t *testing.T) {
+		t.Parallel()
+		schedule := upgrade(t, models.ReverseETLScheduleStrategyDbtCloud, jsontypes.NewNormalizedValue(`{"jobId":"123","accountId":"456"}`))
+
+		require.NotNil(t, schedule.DbtCloud)
+		assert.Equal(t, "123", schedule.DbtCloud.JobID.ValueString())
+		assert.Equal(t, "456", schedule.DbtCloud.AccountID.ValueString())
+	})
+
+	t.Run("manual", func(t *testing.T) {
+		t.Parallel()
+		schedule := upgrade(t, models.ReverseETLScheduleStrategyManual, jsontypes.NewNormalizedNull())
+
+		assert.Nil(t, schedule.Periodic)
+		assert.Nil(t, schedule.SpecificDays)
+		assert.Nil(t, schedule.Cron)
+		assert.Nil(t, schedule.DbtCloud)
+		assert.Nil(t, schedule.NextRuns)
+	})
+
+	t.Run("undecodable config", func(t *testing.T) {
+		t.Parallel()
+		// The typed config is set by the next refresh
+		schedule := upgrade(t, models.ReverseETLScheduleStrategyPeriodic, jsontypes.NewNormalizedValue(`{"interval":1}`))
+
+		assert.Nil(t, schedule.Periodic)
+		assert.Nil(t, schedule.NextRuns)
+	})
+}
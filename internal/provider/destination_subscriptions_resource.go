@@ -0,0 +1,544 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+
+	"github.com/segmentio/public-api-sdk-go/api"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/docs"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
+)
+
+var (
+	_ resource.Resource                = &destinationSubscriptionsResource{}
+	_ resource.ResourceWithConfigure   = &destinationSubscriptionsResource{}
+	_ resource.ResourceWithImportState = &destinationSubscriptionsResource{}
+)
+
+func NewDestinationSubscriptionsResource() resource.Resource {
+	return &destinationSubscriptionsResource{}
+}
+
+type destinationSubscriptionsResource struct {
+	client      *api.APIClient
+	authContext context.Context
+}
+
+func (r *destinationSubscriptionsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_destination_subscriptions"
+}
+
+func (r *destinationSubscriptionsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Configures the complete set of subscriptions of a Destination. Existing subscriptions with the same name and action are adopted when the resource is created, and creating the resource fails when the Destination has other subscriptions, which must then be added to the configuration, imported or removed first. Once the resource is created, subscriptions of the Destination that are not in the configuration, such as the ones created in the Segment app, are shown as drift and removed on apply. This resource should not be used together with `segment_destination_subscription` for the same Destination. For more information, visit the [Segment docs](https://segment.com/docs/connections/destinations/actions/).\n\n" +
+			docs.GenerateImportDocs("<destination_id>", "segment_destination_subscriptions"),
+		Attributes: map[string]schema.Attribute{
+			"destination_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The id of the Destination.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"subscriptions": schema.ListNestedAttribute{
+				Required:    true,
+				Description: "The subscriptions of the Destination.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The unique identifier for the subscription.",
+						},
+						"destination_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The associated Destination instance id.",
+						},
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "The name of the subscription.",
+						},
+						"enabled": schema.BoolAttribute{
+							Required:    true,
+							Description: "Is the subscription enabled.",
+						},
+						"action_id": schema.StringAttribute{
+							Required:    true,
+							Description: "The unique identifier for the Destination action to trigger.",
+						},
+						"action_slug": schema.StringAttribute{
+							Computed:    true,
+							Description: "The URL-friendly key for the associated Destination action.",
+						},
+						"trigger": schema.StringAttribute{
+							Required:    true,
+							Description: "FQL string that describes what events should trigger a Destination action.",
+						},
+						"model_id": schema.StringAttribute{
+							Optional:    true,
+							Description: "The unique identifier for the linked ReverseETLModel, if this part of a Reverse ETL connection.",
+						},
+						"settings": schema.StringAttribute{
+							Required:    true,
+							Description: "The customer settings for action fields. Only settings included in the configuration will be managed by Terraform.",
+							CustomType:  jsontypes.NormalizedType{},
+						},
+						"reverse_etl_schedule": reverseETLScheduleSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *destinationSubscriptionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan models.DestinationSubscriptionsPlan
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, diags := r.applySubscriptions(ctx, plan, nil, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *destinationSubscriptionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var previousState models.DestinationSubscriptionsState
+	diags := req.State.Get(ctx, &previousState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subscriptions, statusCode, err := listDestinationSubscriptions(r.authContext, r.client, previousState.DestinationID.ValueString())
+	if err != nil {
+		if statusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Destination subscriptions (ID: %s)", previousState.DestinationID.ValueString()),
+			err.Error(),
+		)
+
+		return
+	}
+
+	remaining := map[string]api.DestinationSubscription{}
+	for _, subscription := range subscriptions {
+		remaining[subscription.Id] = subscription
+	}
+
+	var state models.DestinationSubscriptionsState
+	state.DestinationID = previousState.DestinationID
+	state.Subscriptions = []models.DestinationSubscriptionState{}
+
+	// Managed subscriptions keep their order and only the settings present in the configuration
+	for _, previousSubscription := range previousState.Subscriptions {
+		subscription, ok := remaining[previousSubscription.ID.ValueString()]
+		if !ok {
+			continue
+		}
+		delete(remaining, subscription.Id)
+
+		var subscriptionState models.DestinationSubscriptionState
+		err = subscriptionState.Fill(subscription)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to populate Destination subscription state",
+				err.Error(),
+			)
+
+			return
+		}
+		subscriptionState.ReverseETLSchedule.KeepNextRuns(ctx, previousSubscription.ReverseETLSchedule)
+
+		if !previousSubscription.Settings.IsNull() && !previousSubscription.Settings.IsUnknown() {
+			mergedSettings, err := mergeSettings(previousSubscription.Settings, subscriptionState.Settings, false)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to merge Destination subscription settings",
+					err.Error(),
+				)
+
+				return
+			}
+			subscriptionState.Settings = mergedSettings
+		}
+
+		state.Subscriptions = append(state.Subscriptions, subscriptionState)
+	}
+
+	// Unmanaged subscriptions are added at the end so that they show up as drift
+	for _, subscription := range subscriptions {
+		if _, ok := remaining[subscription.Id]; !ok {
+			continue
+		}
+
+		var subscriptionState models.DestinationSubscriptionState
+		err = subscriptionState.Fill(subscription)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to populate Destination subscription state",
+				err.Error(),
+			)
+
+			return
+		}
+
+		state.Subscriptions = append(state.Subscriptions, subscriptionState)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *destinationSubscriptionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan models.DestinationSubscriptionsPlan
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var previousState models.DestinationSubscriptionsState
+	diags = req.State.Get(ctx, &previousState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, diags := r.applySubscriptions(ctx, plan, previousState.Subscriptions, true)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *destinationSubscriptionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var config models.DestinationSubscriptionsState
+	diags := req.State.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, subscription := range config.Subscriptions {
+		_, body, err := r.client.DestinationsAPI.RemoveSubscriptionFromDestination(r.authContext, config.DestinationID.ValueString(), subscription.ID.ValueString()).Execute()
+		if body != nil {
+			defer body.Body.Close()
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Unable to delete Destination subscription (ID: %s)", subscription.ID.ValueString()),
+				getError(err, body),
+			)
+
+			return
+		}
+	}
+}
+
+func (r *destinationSubscriptionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("destination_id"), req, resp)
+}
+
+func (r *destinationSubscriptionsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected ClientInfo, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = config.client
+	r.authContext = config.authContext
+}
+
+// applySubscriptions makes the subscriptions of the Destination match the plan. Existing subscriptions are
+// matched by name and action first, then by their position in the previous state. The remaining ones are removed
+// when removeUnmatched is set, and otherwise fail the apply before anything is changed.
+func (r *destinationSubscriptionsResource) applySubscriptions(ctx context.Context, plan models.DestinationSubscriptionsPlan, previousSubscriptions []models.DestinationSubscriptionState, removeUnmatched bool) (*models.DestinationSubscriptionsState, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	destinationID := plan.DestinationID.ValueString()
+
+	for _, subscription := range plan.Subscriptions {
+		if !subscription.ModelID.IsNull() && !subscription.ModelID.IsUnknown() && (subscription.ReverseETLSchedule.IsNull() || subscription.ReverseETLSchedule.IsUnknown()) {
+			diags.AddError(
+				"Reverse ETL model ID provided without reverse ETL schedule",
+				fmt.Sprintf("Reverse ETL model ID of subscription %q must be provided with a reverse ETL schedule", subscription.Name.ValueString()),
+			)
+
+			return nil, diags
+		}
+	}
+
+	subscriptions, _, err := listDestinationSubscriptions(r.authContext, r.client, destinationID)
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("Unable to read Destination subscriptions (ID: %s)", destinationID),
+			err.Error(),
+		)
+
+		return nil, diags
+	}
+
+	matched := map[string]bool{}
+	matches := make([]*api.DestinationSubscription, len(plan.Subscriptions))
+	for i, plannedSubscription := range plan.Subscriptions {
+		for j, subscription := range subscriptions {
+			if !matched[subscription.Id] && subscription.Name == plannedSubscription.Name.ValueString() && subscription.ActionId == plannedSubscription.ActionID.ValueString() {
+				matches[i] = &subscriptions[j]
+				matched[subscription.Id] = true
+
+				break
+			}
+		}
+	}
+	for i, plannedSubscription := range plan.Subscriptions {
+		if matches[i] != nil || i >= len(previousSubscriptions) {
+			continue
+		}
+
+		for j, subscription := range subscriptions {
+			if !matched[subscription.Id] && subscription.Id == previousSubscriptions[i].ID.ValueString() && subscription.ActionId == plannedSubscription.ActionID.ValueString() {
+				matches[i] = &subscriptions[j]
+				matched[subscription.Id] = true
+
+				break
+			}
+		}
+	}
+
+	var unmatched []string
+	for _, subscription := range subscriptions {
+		if !matched[subscription.Id] {
+			unmatched = append(unmatched, fmt.Sprintf("%q (ID: %s)", subscription.Name, subscription.Id))
+		}
+	}
+	if len(unmatched) > 0 && !removeUnmatched {
+		diags.AddError(
+			fmt.Sprintf("Destination has unmanaged subscriptions (ID: %s)", destinationID),
+			fmt.Sprintf("The following subscriptions are not in the configuration: %s. Add them to the configuration, import this resource with the Destination id, or remove them in the Segment app, then apply again.", strings.Join(unmatched, ", ")),
+		)
+
+		return nil, diags
+	}
+
+	for _, subscription := range subscriptions {
+		if matched[subscription.Id] {
+			continue
+		}
+
+		_, body, err := r.client.DestinationsAPI.RemoveSubscriptionFromDestination(r.authContext, destinationID, subscription.Id).Execute()
+		if body != nil {
+			defer body.Body.Close()
+		}
+		if err != nil {
+			diags.AddError(
+				fmt.Sprintf("Unable to delete Destination subscription (ID: %s)", subscription.Id),
+				getError(err, body),
+			)
+
+			return nil, diags
+		}
+	}
+
+	previousSubscriptionsByID := map[string]models.DestinationSubscriptionState{}
+	for _, previousSubscription := range previousSubscriptions {
+		previousSubscriptionsByID[previousSubscription.ID.ValueString()] = previousSubscription
+	}
+
+	var state models.DestinationSubscriptionsState
+	state.DestinationID = plan.DestinationID
+	state.Subscriptions = []models.DestinationSubscriptionState{}
+
+	for i, plannedSubscription := range plan.Subscriptions {
+		var subscription api.DestinationSubscription
+		var d diag.Diagnostics
+
+		switch {
+		case matches[i] == nil:
+			subscription, d = r.createSubscription(ctx, destinationID, plannedSubscription)
+		case isSubscriptionUnchanged(plannedSubscription, previousSubscriptionsByID[matches[i].Id]):
+			subscription = *matches[i]
+		default:
+			subscription, d = r.updateSubscription(ctx, destinationID, matches[i].Id, plannedSubscription)
+		}
+		diags.Append(d...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		var subscriptionState models.DestinationSubscriptionState
+		err = subscriptionState.Fill(subscription)
+		if err != nil {
+			diags.AddError(
+				"Unable to populate Destination subscription state",
+				err.Error(),
+			)
+
+			return nil, diags
+		}
+		subscriptionState.ReverseETLSchedule.KeepNextRuns(ctx, previousSubscriptionsByID[subscription.Id].ReverseETLSchedule)
+
+		// This is to satisfy terraform requirements that the returned fields must match the input ones because new settings can be generated in the response
+		subscriptionState.Settings = plannedSubscription.Settings
+
+		state.Subscriptions = append(state.Subscriptions, subscriptionState)
+	}
+
+	return &state, diags
+}
+
+func (r *destinationSubscriptionsResource) createSubscription(ctx context.Context, destinationID string, plan models.DestinationSubscriptionPlan) (api.DestinationSubscription, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var settings map[string]interface{}
+	diags.Append(plan.Settings.Unmarshal(&settings)...)
+	if diags.HasError() {
+		return api.DestinationSubscription{}, diags
+	}
+
+	out, body, err := r.client.DestinationsAPI.CreateDestinationSubscription(r.authContext, destinationID).CreateDestinationSubscriptionAlphaInput(api.CreateDestinationSubscriptionAlphaInput{
+		Name:     plan.Name.ValueString(),
+		ActionId: plan.ActionID.ValueString(),
+		Trigger:  plan.Trigger.ValueString(),
+		Enabled:  plan.Enabled.ValueBool(),
+		ModelId:  plan.ModelID.ValueStringPointer(),
+		Settings: settings,
+	}).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("Unable to create Destination subscription %q", plan.Name.ValueString()),
+			getError(err, body),
+		)
+
+		return api.DestinationSubscription{}, diags
+	}
+
+	// The reverse ETL schedule can only be set by updating the subscription
+	return r.updateSubscription(ctx, destinationID, out.Data.DestinationSubscription.Id, plan)
+}
+
+func (r *destinationSubscriptionsResource) updateSubscription(ctx context.Context, destinationID string, subscriptionID string, plan models.DestinationSubscriptionPlan) (api.DestinationSubscription, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var settings map[string]interface{}
+	diags.Append(plan.Settings.Unmarshal(&settings)...)
+	if diags.HasError() {
+		return api.DestinationSubscription{}, diags
+	}
+
+	reverseETLSchedule, d := getSchedule(ctx, plan.ReverseETLSchedule)
+	diags.Append(d...)
+	if diags.HasError() {
+		return api.DestinationSubscription{}, diags
+	}
+
+	out, body, err := r.client.DestinationsAPI.UpdateSubscriptionForDestination(r.authContext, destinationID, subscriptionID).UpdateSubscriptionForDestinationAlphaInput(api.UpdateSubscriptionForDestinationAlphaInput{
+		Input: api.DestinationSubscriptionUpdateInput{
+			Name:               plan.Name.ValueStringPointer(),
+			Trigger:            plan.Trigger.ValueStringPointer(),
+			Enabled:            plan.Enabled.ValueBoolPointer(),
+			Settings:           settings,
+			ReverseETLModelId:  plan.ModelID.ValueStringPointer(),
+			ReverseETLSchedule: reverseETLSchedule,
+		},
+	}).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("Unable to update Destination subscription (ID: %s)", subscriptionID),
+			getError(err, body),
+		)
+
+		return api.DestinationSubscription{}, diags
+	}
+
+	return out.Data.GetSubscription(), diags
+}
+
+// isSubscriptionUnchanged returns whether the planned subscription matches its previous state, so it does not need to be updated.
+func isSubscriptionUnchanged(plan models.DestinationSubscriptionPlan, previous models.DestinationSubscriptionState) bool {
+	return previous.ID.ValueString() != "" &&
+		plan.Name.Equal(previous.Name) &&
+		plan.Enabled.Equal(previous.Enabled) &&
+		plan.Trigger.Equal(previous.Trigger) &&
+		plan.ModelID.Equal(previous.ModelID) &&
+		plan.Settings.Equal(previous.Settings) &&
+		plan.ReverseETLSchedule.IsNull() && previous.ReverseETLSchedule == nil
+}
+
+// listDestinationSubscriptions returns every subscription of the Destination, along with the status code of the failed request, if any.
+func listDestinationSubscriptions(authContext context.Context, client *api.APIClient, destinationID string) ([]api.DestinationSubscription, int, error) {
+	subscriptions := []api.DestinationSubscription{}
+	paginationInput := *api.NewPaginationInput(MaxPageSize)
+
+	for {
+		out, body, err := client.DestinationsAPI.ListSubscriptionsFromDestination(authContext, destinationID).Pagination(paginationInput).Execute()
+		if body != nil {
+			defer body.Body.Close()
+		}
+		if err != nil {
+			statusCode := 0
+			if body != nil {
+				statusCode = body.StatusCode
+			}
+
+			return nil, statusCode, errors.New(getError(err, body))
+		}
+
+		subscriptions = append(subscriptions, out.Data.GetSubscriptions()...)
+
+		next := out.Data.GetPagination().Next.Get()
+		if next == nil {
+			return subscriptions, 0, nil
+		}
+		paginationInput.SetCursor(*next)
+	}
+}
@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/segmentio/public-api-sdk-go/api"
+)
+
+func TestAccDestinationSubscriptionsResource(t *testing.T) {
+	t.Parallel()
+
+	// The subscription created in the Segment app prevents the resource from being created until it is removed
+	subscriptions := []api.DestinationSubscription{
+		{
+			Id:            "my-ui-subscription-id",
+			Name:          "My UI subscription",
+			ActionId:      "my-action-id",
+			ActionSlug:    "my-action-slug",
+			DestinationId: "my-destination-id",
+			Enabled:       true,
+			Settings:      map[string]interface{}{},
+			Trigger:       "type = \"identify\"",
+		},
+	}
+	createdSubscriptions := 0
+
+	fakeServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("content-type", "application/json")
+
+			var payload map[string]interface{}
+			if req.URL.Path == "/destinations/my-destination-id/subscriptions" && req.Method == http.MethodGet {
+				payload = map[string]interface{}{"subscriptions": subscriptions, "pagination": map[string]interface{}{"current": "MA=="}}
+			} else if req.URL.Path == "/destinations/my-destination-id/subscriptions" && req.Method == http.MethodPost {
+				var input api.CreateDestinationSubscriptionAlphaInput
+				_ = json.NewDecoder(req.Body).Decode(&input)
+
+				createdSubscriptions++
+				subscription := api.DestinationSubscription{
+					Id:            "my-subscription-id-" + strconv.Itoa(createdSubscriptions),
+					Name:          input.Name,
+					ActionId:      input.ActionId,
+					ActionSlug:    "my-action-slug",
+					DestinationId: "my-destination-id",
+					Enabled:       input.Enabled,
+					Settings:      input.Settings,
+					Trigger:       input.Trigger,
+				}
+				subscriptions = append(subscriptions, subscription)
+				payload = map[string]interface{}{"destinationSubscription": subscription}
+			} else if strings.HasPrefix(req.URL.Path, "/destinations/my-destination-id/subscriptions/") {
+				id := strings.TrimPrefix(req.URL.Path, "/destinations/my-destination-id/subscriptions/")
+				for i, subscription := range subscriptions {
+					if subscription.Id != id {
+						continue
+					}
+
+					if req.Method == http.MethodDelete {
+						subscriptions = append(subscriptions[:i], subscriptions[i+1:]...)
+						payload = map[string]interface{}{"status": "SUCCESS"}
+
+						break
+					}
+
+					var input api.UpdateSubscriptionForDestinationAlphaInput
+					_ = json.NewDecoder(req.Body).Decode(&input)
+					subscriptions[i].Name = *input.Input.Name
+					subscriptions[i].Enabled = *input.Input.Enabled
+					subscriptions[i].Trigger = *input.Input.Trigger
+					subscriptions[i].Settings = input.Input.Settings
+					payload = map[string]interface{}{"subscription": subscriptions[i]}
+
+					break
+				}
+			}
+
+			out, _ := json.Marshal(map[string]interface{}{"data": payload})
+			_, _ = w.Write(out)
+		}),
+	)
+	defer fakeServer.Close()
+
+	providerConfig := `
+		provider "segment" {
+			url   = "` + fakeServer.URL + `"
+			token = "abc123"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Unmanaged subscriptions are not removed when the resource is created
+			{
+				Config: providerConfig + `
+					resource "segment_destination_subscriptions" "test" {
+						destination_id = "my-destination-id"
+						subscriptions = [
+							{
+								name      = "My subscription"
+								enabled   = true
+								action_id = "my-action-id"
+								trigger   = "type = \"track\""
+								settings  = jsonencode({ "url" : "https://example.com" })
+							},
+						]
+					}
+				`,
+				ExpectError: regexp.MustCompile(`"My UI subscription" \(ID: my-ui-subscription-id\)`),
+			},
+			// Create and Read testing
+			{
+				PreConfig: func() {
+					// The subscription is removed in the Segment app
+					subscriptions = []api.DestinationSubscription{}
+				},
+				Config: providerConfig + `
+					resource "segment_destination_subscriptions" "test" {
+						destination_id = "my-destination-id"
+						subscriptions = [
+							{
+								name      = "My subscription"
+								enabled   = true
+								action_id = "my-action-id"
+								trigger   = "type = \"track\""
+								settings  = jsonencode({ "url" : "https://example.com" })
+							},
+						]
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_destination_subscriptions.test", "destination_id", "my-destination-id"),
+					resource.TestCheckResourceAttr("segment_destination_subscriptions.test", "subscriptions.#", "1"),
+					resource.TestCheckResourceAttr("segment_destination_subscriptions.test", "subscriptions.0.id", "my-subscription-id-1"),
+					resource.TestCheckResourceAttr("segment_destination_subscriptions.test", "subscriptions.0.name", "My subscription"),
+					resource.TestCheckResourceAttr("segment_destination_subscriptions.test", "subscriptions.0.action_slug", "my-action-slug"),
+					resource.TestCheckResourceAttr("segment_destination_subscriptions.test", "subscriptions.0.settings", "{\"url\":\"https://example.com\"}"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:  "segment_destination_subscriptions.test",
+				ImportState:   true,
+				ImportStateId: "my-destination-id",
+			},
+			// Update and Read testing
+			{
+				PreConfig: func() {
+					// A subscription created in the Segment app shows up as drift and is removed
+					subscriptions = append(subscriptions, api.DestinationSubscription{
+						Id:            "my-other-ui-subscription-id",
+						Name:          "My other UI subscription",
+						ActionId:      "my-action-id",
+						ActionSlug:    "my-action-slug",
+						DestinationId: "my-destination-id",
+						Enabled:       true,
+						Settings:      map[string]interface{}{},
+						Trigger:       "type = \"page\"",
+					})
+				},
+				Config: providerConfig + `
+					resource "segment_destination_subscriptions" "test" {
+						destination_id = "my-destination-id"
+						subscriptions = [
+							{
+								name      = "My renamed subscription"
+								enabled   = false
+								action_id = "my-action-id"
+								trigger   = "type = \"track\""
+								settings  = jsonencode({ "url" : "https://example.com" })
+							},
+							{
+								name      = "My new subscription"
+								enabled   = true
+								action_id = "my-action-id"
+								trigger   = "type = \"identify\""
+								settings  = jsonencode({})
+							},
+						]
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_destination_subscriptions.test", "subscriptions.#", "2"),
+					resource.TestCheckResourceAttr("segment_destination_subscriptions.test", "subscriptions.0.id", "my-subscription-id-1"),
+					resource.TestCheckResourceAttr("segment_destination_subscriptions.test", "subscriptions.0.name", "My renamed subscription"),
+					resource.TestCheckResourceAttr("segment_destination_subscriptions.test", "subscriptions.0.enabled", "false"),
+					resource.TestCheckResourceAttr("segment_destination_subscriptions.test", "subscriptions.1.id", "my-subscription-id-2"),
+					resource.TestCheckResourceAttr("segment_destination_subscriptions.test", "subscriptions.1.name", "My new subscription"),
+				),
+			},
+		},
+	})
+}
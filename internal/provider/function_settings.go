@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/segmentio/public-api-sdk-go/api"
+)
+
+// planFunctionMetadata resolves `metadata` from the catalog id of the Function set in `function_id`, requires the resource
+// to be replaced when the catalog id changes, and validates `settings` against the settings declared by the Function.
+func planFunctionMetadata(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, authContext context.Context, client *api.APIClient, resourceType string) {
+	// Nothing to do when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	functionIDPath := path.Root("function_id")
+	metadataPath := path.Root("metadata")
+	metadataIDPath := metadataPath.AtName("id")
+
+	var functionID types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, functionIDPath, &functionID)...)
+	if resp.Diagnostics.HasError() || functionID.IsNull() {
+		return
+	}
+
+	metadataType, diags := req.Plan.Schema.TypeAtPath(ctx, metadataPath)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var stateMetadataID types.String
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, metadataIDPath, &stateMetadataID)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if functionID.IsUnknown() {
+		unknownMetadata, err := metadataType.ValueFromTerraform(ctx, tftypes.NewValue(metadataType.TerraformType(ctx), tftypes.UnknownValue))
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(metadataPath, "Unable to plan metadata", err.Error())
+
+			return
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, metadataPath, unknownMetadata)...)
+		if !req.State.Raw.IsNull() {
+			resp.RequiresReplace.Append(functionIDPath)
+		}
+
+		return
+	}
+
+	out, body, err := client.FunctionsAPI.GetFunction(authContext, functionID.ValueString()).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			functionIDPath,
+			fmt.Sprintf("Unable to read Function (ID: %s)", functionID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+	function := out.Data.GetFunction()
+
+	if function.GetResourceType() != resourceType {
+		resp.Diagnostics.AddAttributeError(
+			functionIDPath,
+			"Unexpected Function type",
+			fmt.Sprintf("Function (ID: %s) is a %s Function, expected a %s Function.", functionID.ValueString(), function.GetResourceType(), resourceType),
+		)
+
+		return
+	}
+	if function.GetCatalogId() == "" {
+		resp.Diagnostics.AddAttributeError(
+			functionIDPath,
+			"Function is not in the catalog",
+			fmt.Sprintf("Function (ID: %s) has no catalog id. Make sure it has been deployed.", functionID.ValueString()),
+		)
+
+		return
+	}
+	catalogID := function.GetCatalogId()
+
+	var settings jsontypes.Normalized
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("settings"), &settings)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !settings.IsNull() && !settings.IsUnknown() {
+		var settingsMap map[string]interface{}
+		resp.Diagnostics.Append(settings.Unmarshal(&settingsMap)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		for _, message := range validateFunctionSettings(function.Settings, settingsMap) {
+			resp.Diagnostics.AddAttributeError(path.Root("settings"), "Invalid Function settings", message)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if stateMetadataID.ValueString() == catalogID {
+		return
+	}
+
+	// The rest of the metadata is only known once the resource is created from the catalog id
+	metadataAttributeTypes := metadataType.(attr.TypeWithAttributeTypes).AttributeTypes()
+	metadataAttributes := map[string]attr.Value{}
+	for name, attributeType := range metadataAttributeTypes {
+		value, err := attributeType.ValueFromTerraform(ctx, tftypes.NewValue(attributeType.TerraformType(ctx), tftypes.UnknownValue))
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(metadataPath, "Unable to plan metadata", err.Error())
+
+			return
+		}
+		metadataAttributes[name] = value
+	}
+	metadataAttributes["id"] = types.StringValue(catalogID)
+
+	metadata, diags := types.ObjectValue(metadataAttributeTypes, metadataAttributes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, metadataPath, metadata)...)
+
+	if !req.State.Raw.IsNull() {
+		resp.RequiresReplace.Append(functionIDPath)
+	}
+}
+
+// validateFunctionSettings returns a message for every setting that does not match the settings declared by the Function.
+func validateFunctionSettings(declaredSettings []api.FunctionSettingV1, settings map[string]interface{}) []string {
+	messages := []string{}
+	declared := map[string]bool{}
+
+	for _, setting := range declaredSettings {
+		declared[setting.Name] = true
+
+		value, ok := settings[setting.Name]
+		if !ok || value == nil {
+			if setting.Required {
+				messages = append(messages, fmt.Sprintf("Setting %q is required by the Function.", setting.Name))
+			}
+
+			continue
+		}
+
+		valid := true
+		switch setting.Type {
+		case "STRING":
+			_, valid = value.(string)
+		case "BOOLEAN":
+			_, valid = value.(bool)
+		case "ARRAY":
+			_, valid = value.([]interface{})
+		case "TEXT_MAP":
+			_, valid = value.(map[string]interface{})
+		}
+		if !valid {
+			messages = append(messages, fmt.Sprintf("Setting %q must be of type %s.", setting.Name, setting.Type))
+		}
+	}
+
+	for name := range settings {
+		if !declared[name] {
+			messages = append(messages, fmt.Sprintf("Setting %q is not declared by the Function.", name))
+		}
+	}
+	slices.Sort(messages)
+
+	return messages
+}
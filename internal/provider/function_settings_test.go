@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/segmentio/public-api-sdk-go/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFunctionSettings(t *testing.T) {
+	t.Parallel()
+
+	declared := []api.FunctionSettingV1{
+		{Name: "apiKey", Type: "STRING", Required: true, Sensitive: true},
+		{Name: "enabled", Type: "BOOLEAN"},
+		{Name: "events", Type: "ARRAY"},
+		{Name: "mapping", Type: "TEXT_MAP"},
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+		messages := validateFunctionSettings(declared, map[string]interface{}{
+			"apiKey":  "secret",
+			"enabled": true,
+			"events":  []interface{}{"Order Completed"},
+			"mapping": map[string]interface{}{"a": "b"},
+		})
+
+		assert.Empty(t, messages)
+	})
+
+	t.Run("optional settings omitted", func(t *testing.T) {
+		t.Parallel()
+		messages := validateFunctionSettings(declared, map[string]interface{}{"apiKey": "secret"})
+
+		assert.Empty(t, messages)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		messages := validateFunctionSettings(declared, map[string]interface{}{
+			"enabled": "true",
+			"events":  "Order Completed",
+			"unknown": 1,
+		})
+
+		assert.Equal(t, []string{
+			`Setting "apiKey" is required by the Function.`,
+			`Setting "enabled" must be of type BOOLEAN.`,
+			`Setting "events" must be of type ARRAY.`,
+			`Setting "unknown" is not declared by the Function.`,
+		}, messages)
+	})
+}
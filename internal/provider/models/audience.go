@@ -0,0 +1,77 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/segmentio/public-api-sdk-go/api"
+)
+
+type AudienceState struct {
+	ID                    types.String                `tfsdk:"id"`
+	SpaceID               types.String                `tfsdk:"space_id"`
+	Name                  types.String                `tfsdk:"name"`
+	Description           types.String                `tfsdk:"description"`
+	Key                   types.String                `tfsdk:"key"`
+	Enabled               types.Bool                  `tfsdk:"enabled"`
+	Definition            *ComputationDefinitionState `tfsdk:"definition"`
+	IncludeHistoricalData types.Bool                  `tfsdk:"include_historical_data"`
+	IncludeAnonymousUsers types.Bool                  `tfsdk:"include_anonymous_users"`
+}
+
+type AudienceDataSourceState struct {
+	ID                    types.String                `tfsdk:"id"`
+	SpaceID               types.String                `tfsdk:"space_id"`
+	Name                  types.String                `tfsdk:"name"`
+	Description           types.String                `tfsdk:"description"`
+	Key                   types.String                `tfsdk:"key"`
+	Enabled               types.Bool                  `tfsdk:"enabled"`
+	Definition            *ComputationDefinitionState `tfsdk:"definition"`
+	IncludeHistoricalData types.Bool                  `tfsdk:"include_historical_data"`
+	IncludeAnonymousUsers types.Bool                  `tfsdk:"include_anonymous_users"`
+	Status                types.String                `tfsdk:"status"`
+	CreatedAt             types.String                `tfsdk:"created_at"`
+	UpdatedAt             types.String                `tfsdk:"updated_at"`
+}
+
+func (a *AudienceState) Fill(audience api.AudienceSummary) {
+	a.ID = types.StringValue(audience.Id)
+	a.SpaceID = types.StringValue(audience.SpaceId)
+	a.Name = types.StringValue(audience.Name)
+	a.Description = types.StringNull()
+	if audience.Description != nil && *audience.Description != "" {
+		a.Description = types.StringValue(*audience.Description)
+	}
+	a.Key = types.StringValue(audience.Key)
+	a.Enabled = types.BoolValue(audience.Enabled)
+
+	a.Definition = nil
+	if definition := audience.Definition.Get(); definition != nil {
+		a.Definition = &ComputationDefinitionState{
+			Query: types.StringValue(definition.Query),
+			Type:  types.StringValue(definition.Type),
+		}
+	}
+
+	var options api.AudienceOptions
+	if audience.Options != nil {
+		options = *audience.Options
+	}
+	fillComputationOptions(options.IncludeHistoricalData, options.IncludeAnonymousUsers, &a.IncludeHistoricalData, &a.IncludeAnonymousUsers)
+}
+
+func (a *AudienceDataSourceState) Fill(audience api.AudienceSummary) {
+	var state AudienceState
+	state.Fill(audience)
+
+	a.ID = state.ID
+	a.SpaceID = state.SpaceID
+	a.Name = state.Name
+	a.Description = state.Description
+	a.Key = state.Key
+	a.Enabled = state.Enabled
+	a.Definition = state.Definition
+	a.IncludeHistoricalData = state.IncludeHistoricalData
+	a.IncludeAnonymousUsers = state.IncludeAnonymousUsers
+	a.Status = types.StringPointerValue(audience.Status)
+	a.CreatedAt = types.StringValue(audience.CreatedAt)
+	a.UpdatedAt = types.StringValue(audience.UpdatedAt)
+}
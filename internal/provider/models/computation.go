@@ -0,0 +1,44 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/segmentio/public-api-sdk-go/api"
+)
+
+// ComputationDefinitionState is the query of an Audience or a Computed Trait.
+type ComputationDefinitionState struct {
+	Query types.String `tfsdk:"query"`
+	Type  types.String `tfsdk:"type"`
+}
+
+func (d *ComputationDefinitionState) ToAudienceAPIValue() api.AudienceComputationDefinition {
+	return api.AudienceComputationDefinition{
+		Query: d.Query.ValueString(),
+		Type:  d.Type.ValueString(),
+	}
+}
+
+func (d *ComputationDefinitionState) ToTraitAPIValue() api.TraitDefinition {
+	return api.TraitDefinition{
+		Query: d.Query.ValueString(),
+		Type:  d.Type.ValueString(),
+	}
+}
+
+// fillComputationOptions sets the options of an Audience or a Computed Trait. The API can omit the options, in which
+// case the previous values are kept, and they default to false otherwise.
+func fillComputationOptions(includeHistoricalData, includeAnonymousUsers *bool, historicalDataState, anonymousUsersState *types.Bool) {
+	if includeHistoricalData != nil {
+		*historicalDataState = types.BoolValue(*includeHistoricalData)
+	}
+	if includeAnonymousUsers != nil {
+		*anonymousUsersState = types.BoolValue(*includeAnonymousUsers)
+	}
+
+	if historicalDataState.IsNull() || historicalDataState.IsUnknown() {
+		*historicalDataState = types.BoolValue(false)
+	}
+	if anonymousUsersState.IsNull() || anonymousUsersState.IsUnknown() {
+		*anonymousUsersState = types.BoolValue(false)
+	}
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/segmentio/public-api-sdk-go/api"
+)
+
+type ComputedTraitState struct {
+	ID                    types.String                `tfsdk:"id"`
+	SpaceID               types.String                `tfsdk:"space_id"`
+	Name                  types.String                `tfsdk:"name"`
+	Description           types.String                `tfsdk:"description"`
+	Key                   types.String                `tfsdk:"key"`
+	Enabled               types.Bool                  `tfsdk:"enabled"`
+	Definition            *ComputationDefinitionState `tfsdk:"definition"`
+	IncludeHistoricalData types.Bool                  `tfsdk:"include_historical_data"`
+	IncludeAnonymousUsers types.Bool                  `tfsdk:"include_anonymous_users"`
+	CreatedBy             types.String                `tfsdk:"created_by"`
+	UpdatedBy             types.String                `tfsdk:"updated_by"`
+	UpdatedAt             types.String                `tfsdk:"updated_at"`
+}
+
+func (c *ComputedTraitState) Fill(computedTrait api.ComputedTraitSummary) {
+	c.ID = types.StringValue(computedTrait.Id)
+	c.SpaceID = types.StringValue(computedTrait.SpaceId)
+	c.Name = types.StringValue(computedTrait.Name)
+	c.Description = types.StringNull()
+	if computedTrait.Description != nil && *computedTrait.Description != "" {
+		c.Description = types.StringValue(*computedTrait.Description)
+	}
+	c.Key = types.StringValue(computedTrait.Key)
+	c.Enabled = types.BoolValue(computedTrait.Enabled)
+
+	c.Definition = nil
+	if definition := computedTrait.Definition.Get(); definition != nil {
+		c.Definition = &ComputationDefinitionState{
+			Query: types.StringValue(definition.Query),
+			Type:  types.StringValue(definition.Type),
+		}
+	}
+
+	var options api.TraitOptions
+	if computedTrait.Options != nil {
+		options = *computedTrait.Options
+	}
+	fillComputationOptions(options.IncludeHistoricalData, options.IncludeAnonymousUsers, &c.IncludeHistoricalData, &c.IncludeAnonymousUsers)
+
+	c.CreatedBy = types.StringValue(computedTrait.CreatedBy)
+	c.UpdatedBy = types.StringValue(computedTrait.UpdatedBy)
+	c.UpdatedAt = types.StringValue(computedTrait.UpdatedAt)
+}
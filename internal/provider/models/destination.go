@@ -9,15 +9,40 @@ import (
 )
 
 type DestinationState struct {
-	ID       types.String              `tfsdk:"id"`
-	Name     types.String              `tfsdk:"name"`
-	Enabled  types.Bool                `tfsdk:"enabled"`
-	Metadata *DestinationMetadataState `tfsdk:"metadata"`
-	SourceID types.String              `tfsdk:"source_id"`
-	Settings jsontypes.Normalized      `tfsdk:"settings"`
+	ID                     types.String              `tfsdk:"id"`
+	Name                   types.String              `tfsdk:"name"`
+	Enabled                types.Bool                `tfsdk:"enabled"`
+	Metadata               *DestinationMetadataState `tfsdk:"metadata"`
+	IncludeCatalogMetadata types.Bool                `tfsdk:"include_catalog_metadata"`
+	FunctionID             types.String              `tfsdk:"function_id"`
+	SourceID               types.String              `tfsdk:"source_id"`
+	Settings               jsontypes.Normalized      `tfsdk:"settings"`
 }
 
 type DestinationPlan struct {
+	ID                     types.String         `tfsdk:"id"`
+	Name                   types.String         `tfsdk:"name"`
+	Enabled                types.Bool           `tfsdk:"enabled"`
+	Metadata               types.Object         `tfsdk:"metadata"`
+	IncludeCatalogMetadata types.Bool           `tfsdk:"include_catalog_metadata"`
+	FunctionID             types.String         `tfsdk:"function_id"`
+	SourceID               types.String         `tfsdk:"source_id"`
+	Settings               jsontypes.Normalized `tfsdk:"settings"`
+}
+
+type DestinationDataSourceState struct {
+	ID                     types.String              `tfsdk:"id"`
+	Name                   types.String              `tfsdk:"name"`
+	Enabled                types.Bool                `tfsdk:"enabled"`
+	Metadata               *DestinationMetadataState `tfsdk:"metadata"`
+	IncludeCatalogMetadata types.Bool                `tfsdk:"include_catalog_metadata"`
+	SourceID               types.String              `tfsdk:"source_id"`
+	Settings               jsontypes.Normalized      `tfsdk:"settings"`
+}
+
+// DestinationStateV0 is the state of segment_destination before include_catalog_metadata and function_id were added.
+// The metadata is kept as an object so that this struct does not change with DestinationMetadataState.
+type DestinationStateV0 struct {
 	ID       types.String         `tfsdk:"id"`
 	Name     types.String         `tfsdk:"name"`
 	Enabled  types.Bool           `tfsdk:"enabled"`
@@ -38,6 +63,15 @@ func (d *DestinationState) Fill(destination *api.DestinationV1) error {
 	d.SourceID = types.StringValue(destination.SourceId)
 	d.Enabled = types.BoolValue(destination.Enabled)
 	d.Metadata = &destinationMetadata
+
+	// The catalog metadata is included unless it is explicitly omitted
+	if d.IncludeCatalogMetadata.IsNull() || d.IncludeCatalogMetadata.IsUnknown() {
+		d.IncludeCatalogMetadata = types.BoolValue(true)
+	}
+	if !d.IncludeCatalogMetadata.ValueBool() {
+		d.Metadata.OmitCatalog()
+	}
+
 	settings, err := GetSettingsFromMap(destination.Settings)
 	if err != nil {
 		return err
@@ -47,6 +81,24 @@ func (d *DestinationState) Fill(destination *api.DestinationV1) error {
 	return nil
 }
 
+func (d *DestinationDataSourceState) Fill(destination *api.DestinationV1) error {
+	state := DestinationState{IncludeCatalogMetadata: d.IncludeCatalogMetadata}
+	err := state.Fill(destination)
+	if err != nil {
+		return err
+	}
+
+	d.ID = state.ID
+	d.Name = state.Name
+	d.Enabled = state.Enabled
+	d.Metadata = state.Metadata
+	d.IncludeCatalogMetadata = state.IncludeCatalogMetadata
+	d.SourceID = state.SourceID
+	d.Settings = state.Settings
+
+	return nil
+}
+
 func GetSettingsFromMap(settings map[string]interface{}) (jsontypes.Normalized, error) {
 	jsonSettingsString, err := json.Marshal(settings)
 	if err != nil {
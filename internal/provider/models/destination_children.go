@@ -0,0 +1,12 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type DestinationChildrenState struct {
+	DestinationID           types.String                   `tfsdk:"destination_id"`
+	Subscriptions           []DestinationSubscriptionState `tfsdk:"subscriptions"`
+	Filters                 []DestinationFilterState       `tfsdk:"filters"`
+	InsertFunctionInstances []InsertFunctionInstanceState  `tfsdk:"insert_function_instances"`
+}
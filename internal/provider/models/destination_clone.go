@@ -0,0 +1,40 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type DestinationCloneState struct {
+	ID                    types.String             `tfsdk:"id"`
+	CopyFromDestinationID types.String             `tfsdk:"copy_from_destination_id"`
+	SourceID              types.String             `tfsdk:"source_id"`
+	Name                  types.String             `tfsdk:"name"`
+	Enabled               types.Bool               `tfsdk:"enabled"`
+	Settings              jsontypes.Normalized     `tfsdk:"settings"`
+	SubscriptionSettings  types.Map                `tfsdk:"subscription_settings"`
+	MetadataID            types.String             `tfsdk:"metadata_id"`
+	MaskedSettings        []types.String           `tfsdk:"masked_settings"`
+	Subscriptions         []CopiedDestinationChild `tfsdk:"subscriptions"`
+	Filters               []CopiedDestinationChild `tfsdk:"filters"`
+}
+
+type DestinationClonePlan struct {
+	ID                    types.String         `tfsdk:"id"`
+	CopyFromDestinationID types.String         `tfsdk:"copy_from_destination_id"`
+	SourceID              types.String         `tfsdk:"source_id"`
+	Name                  types.String         `tfsdk:"name"`
+	Enabled               types.Bool           `tfsdk:"enabled"`
+	Settings              jsontypes.Normalized `tfsdk:"settings"`
+	SubscriptionSettings  types.Map            `tfsdk:"subscription_settings"`
+	MetadataID            types.String         `tfsdk:"metadata_id"`
+	MaskedSettings        types.List           `tfsdk:"masked_settings"`
+	Subscriptions         types.List           `tfsdk:"subscriptions"`
+	Filters               types.List           `tfsdk:"filters"`
+}
+
+type CopiedDestinationChild struct {
+	ID           types.String `tfsdk:"id"`
+	CopiedFromID types.String `tfsdk:"copied_from_id"`
+	Name         types.String `tfsdk:"name"`
+}
@@ -375,3 +375,12 @@ func (d *DestinationMetadataState) Fill(destinationMetadata api.DestinationMetad
 
 	return nil
 }
+
+// OmitCatalog keeps only the fields identifying the catalog item and removes the rest of the catalog metadata.
+func (d *DestinationMetadataState) OmitCatalog() {
+	*d = DestinationMetadataState{
+		ID:   d.ID,
+		Name: d.Name,
+		Slug: d.Slug,
+	}
+}
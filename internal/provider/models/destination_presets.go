@@ -0,0 +1,52 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/segmentio/public-api-sdk-go/api"
+)
+
+type DestinationPresetsState struct {
+	MetadataID    types.String         `tfsdk:"metadata_id"`
+	Subscriptions []PresetSubscription `tfsdk:"subscriptions"`
+}
+
+type PresetSubscription struct {
+	Name       types.String         `tfsdk:"name"`
+	ActionID   types.String         `tfsdk:"action_id"`
+	ActionSlug types.String         `tfsdk:"action_slug"`
+	Trigger    types.String         `tfsdk:"trigger"`
+	Settings   jsontypes.Normalized `tfsdk:"settings"`
+}
+
+func (d *DestinationPresetsState) Fill(destinationMetadata api.DestinationMetadataV1) error {
+	var metadata DestinationMetadataState
+	err := metadata.Fill(destinationMetadata)
+	if err != nil {
+		return err
+	}
+
+	actionSlugs := map[string]types.String{}
+	for _, action := range metadata.Actions {
+		actionSlugs[action.ID.ValueString()] = action.Slug
+	}
+
+	d.MetadataID = metadata.ID
+	d.Subscriptions = []PresetSubscription{}
+	for _, preset := range metadata.Presets {
+		actionSlug, ok := actionSlugs[preset.ActionID.ValueString()]
+		if !ok {
+			actionSlug = types.StringNull()
+		}
+
+		d.Subscriptions = append(d.Subscriptions, PresetSubscription{
+			Name:       preset.Name,
+			ActionID:   preset.ActionID,
+			ActionSlug: actionSlug,
+			Trigger:    preset.Trigger,
+			Settings:   preset.Fields,
+		})
+	}
+
+	return nil
+}
@@ -1,7 +1,7 @@
 package models
 
 import (
-	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -21,6 +21,19 @@ type DestinationSubscriptionState struct {
 	ReverseETLSchedule *ReverseETLScheduleState `tfsdk:"reverse_etl_schedule"`
 }
 
+type DestinationSubscriptionStateV0 struct {
+	ID                 types.String               `tfsdk:"id"`
+	DestinationID      types.String               `tfsdk:"destination_id"`
+	Name               types.String               `tfsdk:"name"`
+	Enabled            types.Bool                 `tfsdk:"enabled"`
+	ActionID           types.String               `tfsdk:"action_id"`
+	ActionSlug         types.String               `tfsdk:"action_slug"`
+	Trigger            types.String               `tfsdk:"trigger"`
+	ModelID            types.String               `tfsdk:"model_id"`
+	Settings           jsontypes.Normalized       `tfsdk:"settings"`
+	ReverseETLSchedule *ReverseETLScheduleStateV0 `tfsdk:"reverse_etl_schedule"`
+}
+
 type DestinationSubscriptionPlan struct {
 	ID                 types.String         `tfsdk:"id"`
 	DestinationID      types.String         `tfsdk:"destination_id"`
@@ -34,11 +47,6 @@ type DestinationSubscriptionPlan struct {
 	ReverseETLSchedule types.Object         `tfsdk:"reverse_etl_schedule"`
 }
 
-type ReverseETLScheduleState struct {
-	Strategy types.String         `tfsdk:"strategy"`
-	Config   jsontypes.Normalized `tfsdk:"config"`
-}
-
 func (d *DestinationSubscriptionState) Fill(subscription api.DestinationSubscription) error {
 	d.ID = types.StringValue(subscription.Id)
 	d.DestinationID = types.StringValue(subscription.DestinationId)
@@ -56,35 +64,24 @@ func (d *DestinationSubscriptionState) Fill(subscription api.DestinationSubscrip
 		return err
 	}
 	d.Settings = settings
-	schedule, err := getReverseETLSchedule(subscription.ReverseETLSchedule)
-	if err != nil {
-		return err
+	d.ReverseETLSchedule = nil
+	if subscription.ReverseETLSchedule != nil {
+		d.ReverseETLSchedule = &ReverseETLScheduleState{}
+		err = d.ReverseETLSchedule.Fill(*subscription.ReverseETLSchedule, time.Now())
+		if err != nil {
+			return err
+		}
 	}
-	d.ReverseETLSchedule = schedule
 
 	return nil
 }
 
-func getReverseETLSchedule(schedule *api.ReverseEtlScheduleDefinition) (*ReverseETLScheduleState, error) {
-	if schedule == nil {
-		return nil, nil
-	}
-
-	var config *string
-	if schedule.Config.IsSet() {
-		byteConfig, err := schedule.Config.MarshalJSON()
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal reverse ETL schedule config: %w", err)
-		}
-		stringConfig := string(byteConfig)
-
-		if stringConfig != "null" {
-			config = &stringConfig
-		}
-	}
+type DestinationSubscriptionsState struct {
+	DestinationID types.String                   `tfsdk:"destination_id"`
+	Subscriptions []DestinationSubscriptionState `tfsdk:"subscriptions"`
+}
 
-	return &ReverseETLScheduleState{
-		Strategy: types.StringValue(schedule.Strategy),
-		Config:   jsontypes.NewNormalizedPointerValue(config),
-	}, nil
+type DestinationSubscriptionsPlan struct {
+	DestinationID types.String                  `tfsdk:"destination_id"`
+	Subscriptions []DestinationSubscriptionPlan `tfsdk:"subscriptions"`
 }
@@ -0,0 +1,297 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/robfig/cron/v3"
+	"github.com/segmentio/public-api-sdk-go/api"
+)
+
+const (
+	ReverseETLScheduleStrategyPeriodic     = "PERIODIC"
+	ReverseETLScheduleStrategySpecificDays = "SPECIFIC_DAYS"
+	ReverseETLScheduleStrategyCron         = "CRON"
+	ReverseETLScheduleStrategyDbtCloud     = "DBT_CLOUD"
+	ReverseETLScheduleStrategyManual       = "MANUAL"
+)
+
+// ReverseETLScheduleNextRunsCount is the number of upcoming runs previewed in `next_runs`.
+const ReverseETLScheduleNextRunsCount = 5
+
+type ReverseETLScheduleState struct {
+	Strategy     types.String                         `tfsdk:"strategy"`
+	Config       jsontypes.Normalized                 `tfsdk:"config"`
+	Periodic     *ReverseETLPeriodicScheduleState     `tfsdk:"periodic"`
+	SpecificDays *ReverseETLSpecificDaysScheduleState `tfsdk:"specific_days"`
+	Cron         *ReverseETLCronScheduleState         `tfsdk:"cron"`
+	DbtCloud     *ReverseETLDbtCloudScheduleState     `tfsdk:"dbt_cloud"`
+	NextRuns     []types.String                       `tfsdk:"next_runs"`
+}
+
+type ReverseETLScheduleStateV0 struct {
+	Strategy types.String         `tfsdk:"strategy"`
+	Config   jsontypes.Normalized `tfsdk:"config"`
+}
+
+type ReverseETLPeriodicScheduleState struct {
+	Interval types.String `tfsdk:"interval"`
+}
+
+type ReverseETLSpecificDaysScheduleState struct {
+	Days     []types.Int64 `tfsdk:"days"`
+	Hours    []types.Int64 `tfsdk:"hours"`
+	Timezone types.String  `tfsdk:"timezone"`
+}
+
+type ReverseETLCronScheduleState struct {
+	Spec     types.String `tfsdk:"spec"`
+	Timezone types.String `tfsdk:"timezone"`
+}
+
+type ReverseETLDbtCloudScheduleState struct {
+	JobID     types.String `tfsdk:"job_id"`
+	AccountID types.String `tfsdk:"account_id"`
+}
+
+// Fill sets both the JSON config and the typed config of the strategy, along with the runs following `now`.
+func (s *ReverseETLScheduleState) Fill(schedule api.ReverseEtlScheduleDefinition, now time.Time) error {
+	s.Strategy = types.StringValue(schedule.Strategy)
+	s.Config = jsontypes.NewNormalizedNull()
+	s.Periodic = nil
+	s.SpecificDays = nil
+	s.Cron = nil
+	s.DbtCloud = nil
+	s.NextRuns = nil
+
+	if schedule.Config.IsSet() {
+		byteConfig, err := schedule.Config.MarshalJSON()
+		if err != nil {
+			return fmt.Errorf("failed to marshal reverse ETL schedule config: %w", err)
+		}
+
+		if string(byteConfig) != "null" {
+			s.Config = jsontypes.NewNormalizedValue(string(byteConfig))
+		}
+	}
+
+	config := schedule.Config.Get()
+	if config == nil {
+		return nil
+	}
+
+	switch {
+	case schedule.Strategy == ReverseETLScheduleStrategyPeriodic && config.ReverseEtlPeriodicScheduleConfig != nil:
+		s.Periodic = &ReverseETLPeriodicScheduleState{
+			Interval: types.StringValue(config.ReverseEtlPeriodicScheduleConfig.Interval),
+		}
+	case schedule.Strategy == ReverseETLScheduleStrategySpecificDays && config.ReverseEtlSpecificTimeScheduleConfig != nil:
+		s.SpecificDays = &ReverseETLSpecificDaysScheduleState{
+			Days:     []types.Int64{},
+			Hours:    []types.Int64{},
+			Timezone: types.StringValue(config.ReverseEtlSpecificTimeScheduleConfig.Timezone),
+		}
+		for _, day := range config.ReverseEtlSpecificTimeScheduleConfig.Days {
+			s.SpecificDays.Days = append(s.SpecificDays.Days, types.Int64Value(int64(day)))
+		}
+		for _, hour := range config.ReverseEtlSpecificTimeScheduleConfig.Hours {
+			s.SpecificDays.Hours = append(s.SpecificDays.Hours, types.Int64Value(int64(hour)))
+		}
+	case schedule.Strategy == ReverseETLScheduleStrategyCron && config.ReverseEtlCronScheduleConfig != nil:
+		s.Cron = &ReverseETLCronScheduleState{
+			Spec:     types.StringValue(config.ReverseEtlCronScheduleConfig.Spec),
+			Timezone: types.StringValue(config.ReverseEtlCronScheduleConfig.Timezone),
+		}
+	case schedule.Strategy == ReverseETLScheduleStrategyDbtCloud && config.ReverseEtlDbtCloudScheduleConfig != nil:
+		s.DbtCloud = &ReverseETLDbtCloudScheduleState{
+			JobID:     types.StringValue(config.ReverseEtlDbtCloudScheduleConfig.JobId),
+			AccountID: types.StringValue(config.ReverseEtlDbtCloudScheduleConfig.AccountId),
+		}
+	}
+
+	s.NextRuns = ReverseETLScheduleNextRuns(schedule.Strategy, *config, now)
+
+	return nil
+}
+
+// KeepNextRuns keeps the runs of the previous state when the schedule did not change, so that they are only computed
+// when the schedule is applied rather than on every refresh.
+func (s *ReverseETLScheduleState) KeepNextRuns(ctx context.Context, previous *ReverseETLScheduleState) {
+	if s == nil || previous == nil || previous.NextRuns == nil || !s.Strategy.Equal(previous.Strategy) {
+		return
+	}
+
+	if s.Config.IsNull() || previous.Config.IsNull() {
+		if s.Config.IsNull() && previous.Config.IsNull() {
+			s.NextRuns = previous.NextRuns
+		}
+
+		return
+	}
+
+	equal, diags := s.Config.StringSemanticEquals(ctx, previous.Config)
+	if equal && !diags.HasError() {
+		s.NextRuns = previous.NextRuns
+	}
+}
+
+// ToAPIValue returns the schedule to send to Segment, built from the typed config of the strategy or else from the JSON config.
+func (s *ReverseETLScheduleState) ToAPIValue() (*api.ReverseEtlScheduleDefinition, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	schedule := &api.ReverseEtlScheduleDefinition{Strategy: s.Strategy.ValueString()}
+
+	switch {
+	case s.Periodic != nil:
+		schedule.Config = *api.NewNullableConfig(&api.Config{
+			ReverseEtlPeriodicScheduleConfig: &api.ReverseEtlPeriodicScheduleConfig{
+				Interval: s.Periodic.Interval.ValueString(),
+			},
+		})
+	case s.SpecificDays != nil:
+		config := api.ReverseEtlSpecificTimeScheduleConfig{
+			Days:     []float32{},
+			Hours:    []float32{},
+			Timezone: s.SpecificDays.Timezone.ValueString(),
+		}
+		for _, day := range s.SpecificDays.Days {
+			config.Days = append(config.Days, float32(day.ValueInt64()))
+		}
+		for _, hour := range s.SpecificDays.Hours {
+			config.Hours = append(config.Hours, float32(hour.ValueInt64()))
+		}
+		slices.Sort(config.Days)
+		slices.Sort(config.Hours)
+
+		schedule.Config = *api.NewNullableConfig(&api.Config{
+			ReverseEtlSpecificTimeScheduleConfig: &config,
+		})
+	case s.Cron != nil:
+		schedule.Config = *api.NewNullableConfig(&api.Config{
+			ReverseEtlCronScheduleConfig: &api.ReverseEtlCronScheduleConfig{
+				Spec:     s.Cron.Spec.ValueString(),
+				Timezone: s.Cron.Timezone.ValueString(),
+			},
+		})
+	case s.DbtCloud != nil:
+		schedule.Config = *api.NewNullableConfig(&api.Config{
+			ReverseEtlDbtCloudScheduleConfig: &api.ReverseEtlDbtCloudScheduleConfig{
+				JobId:     s.DbtCloud.JobID.ValueString(),
+				AccountId: s.DbtCloud.AccountID.ValueString(),
+			},
+		})
+	case !s.Config.IsNull() && !s.Config.IsUnknown():
+		config, d := reverseETLScheduleConfigFromJSON(schedule.Strategy, s.Config)
+		diags.Append(d...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		schedule.Config = *api.NewNullableConfig(config)
+	case schedule.Strategy == ReverseETLScheduleStrategyManual:
+		schedule.Config = *api.NewNullableConfig(nil)
+	}
+
+	return schedule, diags
+}
+
+func reverseETLScheduleConfigFromJSON(strategy string, jsonConfig jsontypes.Normalized) (*api.Config, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	config := api.Config{}
+
+	var err error
+	switch strategy {
+	case ReverseETLScheduleStrategyPeriodic:
+		config.ReverseEtlPeriodicScheduleConfig = &api.ReverseEtlPeriodicScheduleConfig{}
+		err = json.Unmarshal([]byte(jsonConfig.ValueString()), config.ReverseEtlPeriodicScheduleConfig)
+	case ReverseETLScheduleStrategySpecificDays:
+		config.ReverseEtlSpecificTimeScheduleConfig = &api.ReverseEtlSpecificTimeScheduleConfig{}
+		err = json.Unmarshal([]byte(jsonConfig.ValueString()), config.ReverseEtlSpecificTimeScheduleConfig)
+	case ReverseETLScheduleStrategyCron:
+		config.ReverseEtlCronScheduleConfig = &api.ReverseEtlCronScheduleConfig{}
+		err = json.Unmarshal([]byte(jsonConfig.ValueString()), config.ReverseEtlCronScheduleConfig)
+	case ReverseETLScheduleStrategyDbtCloud:
+		config.ReverseEtlDbtCloudScheduleConfig = &api.ReverseEtlDbtCloudScheduleConfig{}
+		err = json.Unmarshal([]byte(jsonConfig.ValueString()), config.ReverseEtlDbtCloudScheduleConfig)
+	case ReverseETLScheduleStrategyManual:
+		diags.AddError(
+			"Manual reverse ETL schedule strategy does not require a config",
+			"Manual reverse ETL schedule strategy does not require a config",
+		)
+
+		return nil, diags
+	default:
+		diags.AddError(
+			"Unsupported reverse ETL schedule strategy",
+			fmt.Sprintf("Strategy %q is not supported", strategy),
+		)
+
+		return nil, diags
+	}
+	if err != nil {
+		diags.AddError(
+			"Unable to decode reverse ETL schedule config",
+			err.Error(),
+		)
+
+		return nil, diags
+	}
+
+	return &config, diags
+}
+
+// ReverseETLScheduleNextRuns returns the RFC 3339 times of the next runs of CRON and SPECIFIC_DAYS schedules, or nil
+// for the other strategies and for invalid configs.
+func ReverseETLScheduleNextRuns(strategy string, config api.Config, now time.Time) []types.String {
+	var runs []time.Time
+
+	switch {
+	case strategy == ReverseETLScheduleStrategyCron && config.ReverseEtlCronScheduleConfig != nil:
+		location, err := time.LoadLocation(config.ReverseEtlCronScheduleConfig.Timezone)
+		if err != nil {
+			return nil
+		}
+		schedule, err := cron.ParseStandard(config.ReverseEtlCronScheduleConfig.Spec)
+		if err != nil {
+			return nil
+		}
+
+		next := now.In(location)
+		for len(runs) < ReverseETLScheduleNextRunsCount {
+			next = schedule.Next(next)
+			if next.IsZero() {
+				break
+			}
+			runs = append(runs, next)
+		}
+	case strategy == ReverseETLScheduleStrategySpecificDays && config.ReverseEtlSpecificTimeScheduleConfig != nil:
+		location, err := time.LoadLocation(config.ReverseEtlSpecificTimeScheduleConfig.Timezone)
+		if err != nil {
+			return nil
+		}
+
+		days := config.ReverseEtlSpecificTimeScheduleConfig.Days
+		hours := config.ReverseEtlSpecificTimeScheduleConfig.Hours
+		local := now.In(location)
+		// Every hour of the coming weeks is a candidate, since a single day and hour runs once a week
+		for i := 0; i <= (ReverseETLScheduleNextRunsCount+1)*7*24 && len(runs) < ReverseETLScheduleNextRunsCount; i++ {
+			candidate := time.Date(local.Year(), local.Month(), local.Day(), local.Hour()+i, 0, 0, 0, location)
+			if candidate.After(now) && slices.Contains(days, float32(candidate.Weekday())) && slices.Contains(hours, float32(candidate.Hour())) {
+				runs = append(runs, candidate)
+			}
+		}
+	default:
+		return nil
+	}
+
+	nextRuns := []types.String{}
+	for _, run := range runs {
+		nextRuns = append(nextRuns, types.StringValue(run.Format(time.RFC3339)))
+	}
+
+	return nextRuns
+}
@@ -10,14 +10,26 @@ import (
 )
 
 type SourceTrackingPlanConnectionPlan struct {
+	SourceID                types.String `tfsdk:"source_id"`
+	TrackingPlanID          types.String `tfsdk:"tracking_plan_id"`
+	SchemaSettings          types.Object `tfsdk:"schema_settings"`
+	SchemaSettingsOnDestroy types.String `tfsdk:"schema_settings_on_destroy"`
+}
+
+type SourceTrackingPlanConnectionState struct {
+	SourceID                types.String         `tfsdk:"source_id"`
+	TrackingPlanID          types.String         `tfsdk:"tracking_plan_id"`
+	SchemaSettings          *SchemaSettingsState `tfsdk:"schema_settings"`
+	SchemaSettingsOnDestroy types.String         `tfsdk:"schema_settings_on_destroy"`
+}
+
+type SourceSchemaSettingsPlan struct {
 	SourceID       types.String `tfsdk:"source_id"`
-	TrackingPlanID types.String `tfsdk:"tracking_plan_id"`
 	SchemaSettings types.Object `tfsdk:"schema_settings"`
 }
 
-type SourceTrackingPlanConnectionState struct {
+type SourceSchemaSettingsState struct {
 	SourceID       types.String         `tfsdk:"source_id"`
-	TrackingPlanID types.String         `tfsdk:"tracking_plan_id"`
 	SchemaSettings *SchemaSettingsState `tfsdk:"schema_settings"`
 }
 
@@ -57,6 +69,35 @@ type GroupSettings struct {
 	CommonEventOnViolations types.String `tfsdk:"common_event_on_violations"`
 }
 
+// DefaultSchemaSettings returns the schema settings of a Source that has never been configured.
+func DefaultSchemaSettings() api.SourceSettingsOutputV1 {
+	allow := true
+	commonEventOnViolations := "ALLOW"
+	noForwarding := ""
+
+	return api.SourceSettingsOutputV1{
+		Track: &api.TrackSourceSettingsV1{
+			AllowUnplannedEvents:          &allow,
+			AllowUnplannedEventProperties: &allow,
+			AllowEventOnViolations:        &allow,
+			AllowPropertiesOnViolations:   &allow,
+			CommonEventOnViolations:       &commonEventOnViolations,
+		},
+		Identify: &api.IdentifySourceSettingsV1{
+			AllowUnplannedTraits:    &allow,
+			AllowTraitsOnViolations: &allow,
+			CommonEventOnViolations: &commonEventOnViolations,
+		},
+		Group: &api.GroupSourceSettingsV1{
+			AllowUnplannedTraits:    &allow,
+			AllowTraitsOnViolations: &allow,
+			CommonEventOnViolations: &commonEventOnViolations,
+		},
+		ForwardingViolationsTo:    &noForwarding,
+		ForwardingBlockedEventsTo: &noForwarding,
+	}
+}
+
 func (s *SourceTrackingPlanConnectionState) Fill(sourceID string, trackingPlanID string, schemaSettings *api.SourceSettingsOutputV1) {
 	s.SourceID = types.StringValue(sourceID)
 	s.TrackingPlanID = types.StringValue(trackingPlanID)
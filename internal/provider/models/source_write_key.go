@@ -0,0 +1,11 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type SourceWriteKeyState struct {
+	SourceID types.String `tfsdk:"source_id"`
+	WriteKey types.String `tfsdk:"write_key"`
+	Keepers  types.Map    `tfsdk:"keepers"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/segmentio/public-api-sdk-go/api"
+)
+
+// SpaceState has no connected Sources, since the Public API does not return them for a Space.
+type SpaceState struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Slug types.String `tfsdk:"slug"`
+}
+
+type SpacesState struct {
+	Spaces []SpaceState `tfsdk:"spaces"`
+}
+
+func (s *SpaceState) Fill(space api.Space) {
+	s.ID = types.StringValue(space.Id)
+	s.Name = types.StringValue(space.Name)
+	s.Slug = types.StringValue(space.Slug)
+}
+
+func (s *SpacesState) Fill(spaces []api.Space) {
+	s.Spaces = []SpaceState{}
+	for _, space := range spaces {
+		var state SpaceState
+		state.Fill(space)
+		s.Spaces = append(s.Spaces, state)
+	}
+}
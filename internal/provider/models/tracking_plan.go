@@ -11,14 +11,20 @@ import (
 )
 
 type TrackingPlanDSState struct {
-	ID          types.String   `tfsdk:"id"`
-	Name        types.String   `tfsdk:"name"`
-	Slug        types.String   `tfsdk:"slug"`
-	Description types.String   `tfsdk:"description"`
-	Type        types.String   `tfsdk:"type"`
-	UpdatedAt   types.String   `tfsdk:"updated_at"`
-	CreatedAt   types.String   `tfsdk:"created_at"`
-	Rules       []RulesDSState `tfsdk:"rules"`
+	ID                      types.String            `tfsdk:"id"`
+	Name                    types.String            `tfsdk:"name"`
+	Slug                    types.String            `tfsdk:"slug"`
+	Description             types.String            `tfsdk:"description"`
+	Type                    types.String            `tfsdk:"type"`
+	UpdatedAt               types.String            `tfsdk:"updated_at"`
+	CreatedAt               types.String            `tfsdk:"created_at"`
+	Rules                   []RulesDSState          `tfsdk:"rules"`
+	RuleFilter              *RuleFilterDSState      `tfsdk:"rule_filter"`
+	SourceIDs               []types.String          `tfsdk:"source_ids"`
+	ExportTypewriterPlan    types.Bool              `tfsdk:"export_typewriter_plan"`
+	TypewriterPlan          types.String            `tfsdk:"typewriter_plan"`
+	GenerateTypeDefinitions []types.String          `tfsdk:"generate_type_definitions"`
+	TypeDefinitions         map[string]types.String `tfsdk:"type_definitions"`
 }
 
 type TrackingPlanState struct {
@@ -110,6 +116,13 @@ func (t *TrackingPlanDSState) Fill(trackingPlan api.TrackingPlanV1, rules *[]api
 	return nil
 }
 
+type RuleFilterDSState struct {
+	Type      types.String  `tfsdk:"type"`
+	KeyPrefix types.String  `tfsdk:"key_prefix"`
+	KeyRegex  types.String  `tfsdk:"key_regex"`
+	Version   types.Float64 `tfsdk:"version"`
+}
+
 type RulesState struct {
 	Type       types.String         `tfsdk:"type"`
 	Key        types.String         `tfsdk:"key"`
@@ -156,3 +169,11 @@ func (r *RulesState) ToAPIRuleInput() (api.RuleInputV1, diag.Diagnostics) {
 		JsonSchema: jsonSchema,
 	}, diags
 }
+
+type TrackingPlanFQLDSState struct {
+	TrackingPlanID       types.String `tfsdk:"tracking_plan_id"`
+	TrackEvents          types.String `tfsdk:"track_events"`
+	UnplannedTrackEvents types.String `tfsdk:"unplanned_track_events"`
+	Planned              types.String `tfsdk:"planned"`
+	Unplanned            types.String `tfsdk:"unplanned"`
+}
@@ -9,6 +9,26 @@ import (
 )
 
 type WarehouseState struct {
+	ID                 types.String            `tfsdk:"id"`
+	Metadata           *WarehouseMetadataState `tfsdk:"metadata"`
+	Name               types.String            `tfsdk:"name"`
+	WorkspaceID        types.String            `tfsdk:"workspace_id"`
+	Enabled            types.Bool              `tfsdk:"enabled"`
+	Settings           jsontypes.Normalized    `tfsdk:"settings"`
+	ValidateConnection types.Bool              `tfsdk:"validate_connection"`
+}
+
+type WarehousePlan struct {
+	ID                 types.String         `tfsdk:"id"`
+	Metadata           types.Object         `tfsdk:"metadata"`
+	Name               types.String         `tfsdk:"name"`
+	WorkspaceID        types.String         `tfsdk:"workspace_id"`
+	Enabled            types.Bool           `tfsdk:"enabled"`
+	Settings           jsontypes.Normalized `tfsdk:"settings"`
+	ValidateConnection types.Bool           `tfsdk:"validate_connection"`
+}
+
+type WarehouseDataSourceState struct {
 	ID          types.String            `tfsdk:"id"`
 	Metadata    *WarehouseMetadataState `tfsdk:"metadata"`
 	Name        types.String            `tfsdk:"name"`
@@ -17,15 +37,6 @@ type WarehouseState struct {
 	Settings    jsontypes.Normalized    `tfsdk:"settings"`
 }
 
-type WarehousePlan struct {
-	ID          types.String         `tfsdk:"id"`
-	Metadata    types.Object         `tfsdk:"metadata"`
-	Name        types.String         `tfsdk:"name"`
-	WorkspaceID types.String         `tfsdk:"workspace_id"`
-	Enabled     types.Bool           `tfsdk:"enabled"`
-	Settings    jsontypes.Normalized `tfsdk:"settings"`
-}
-
 func (w *WarehouseState) Fill(warehouse api.WarehouseV1) error {
 	warehouseMetadata := WarehouseMetadataState{}
 	err := warehouseMetadata.Fill(warehouse.Metadata)
@@ -53,6 +64,23 @@ func (w *WarehouseState) Fill(warehouse api.WarehouseV1) error {
 	return nil
 }
 
+func (w *WarehouseDataSourceState) Fill(warehouse api.WarehouseV1) error {
+	var state WarehouseState
+	err := state.Fill(warehouse)
+	if err != nil {
+		return err
+	}
+
+	w.ID = state.ID
+	w.Metadata = state.Metadata
+	w.Name = state.Name
+	w.WorkspaceID = state.WorkspaceID
+	w.Enabled = state.Enabled
+	w.Settings = state.Settings
+
+	return nil
+}
+
 func (w *WarehouseState) getSettings(settings map[string]interface{}) (jsontypes.Normalized, error) {
 	// We remove "name" from the returned settings to surface it as a top level attribute
 	settingsWithoutName := make(map[string]interface{})
@@ -68,3 +96,16 @@ func (w *WarehouseState) getSettings(settings map[string]interface{}) (jsontypes
 
 	return jsontypes.NewNormalizedValue(string(jsonSettingsString)), nil
 }
+
+type WarehouseConnectionTestState struct {
+	MetadataID types.String                   `tfsdk:"metadata_id"`
+	Settings   jsontypes.Normalized           `tfsdk:"settings"`
+	Status     types.String                   `tfsdk:"status"`
+	Valid      types.Bool                     `tfsdk:"valid"`
+	Errors     []WarehouseConnectionTestError `tfsdk:"errors"`
+}
+
+type WarehouseConnectionTestError struct {
+	Setting types.String `tfsdk:"setting"`
+	Message types.String `tfsdk:"message"`
+}
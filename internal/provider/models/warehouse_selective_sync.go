@@ -0,0 +1,121 @@
+package models
+
+import (
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/segmentio/public-api-sdk-go/api"
+)
+
+type WarehouseSelectiveSyncState struct {
+	WarehouseID types.String                            `tfsdk:"warehouse_id"`
+	SourceID    types.String                            `tfsdk:"source_id"`
+	Collections []WarehouseSelectiveSyncCollectionState `tfsdk:"collections"`
+}
+
+type WarehouseSelectiveSyncCollectionState struct {
+	Name               types.String   `tfsdk:"name"`
+	Enabled            types.Bool     `tfsdk:"enabled"`
+	DisabledProperties []types.String `tfsdk:"disabled_properties"`
+}
+
+type ProfilesWarehouseSelectiveSyncState struct {
+	SpaceID           types.String                            `tfsdk:"space_id"`
+	WarehouseID       types.String                            `tfsdk:"warehouse_id"`
+	EnableEventTables types.Bool                              `tfsdk:"enable_event_tables"`
+	Collections       []WarehouseSelectiveSyncCollectionState `tfsdk:"collections"`
+}
+
+// Fill sets the `managed` collections from the Selective Sync items of the Source. When `managed` is nil, as on import,
+// every collection that is disabled or has disabled properties is set instead.
+func (s *WarehouseSelectiveSyncState) Fill(items []api.WarehouseSelectiveSyncItemV1, managed []WarehouseSelectiveSyncCollectionState) {
+	s.Collections = fillSelectiveSyncCollections(items, managed)
+}
+
+// Fill sets the `managed` collections from the Selective Sync items of the Space, like WarehouseSelectiveSyncState.
+func (s *ProfilesWarehouseSelectiveSyncState) Fill(items []api.SpaceWarehouseSelectiveSyncItemAlpha, enableEventTables *bool, managed []WarehouseSelectiveSyncCollectionState) {
+	warehouseItems := []api.WarehouseSelectiveSyncItemV1{}
+	for _, item := range items {
+		warehouseItems = append(warehouseItems, api.WarehouseSelectiveSyncItemV1{
+			Collection:  item.Collection,
+			WarehouseId: item.WarehouseId,
+			Enabled:     item.Enabled,
+			Properties:  item.Properties,
+		})
+	}
+
+	s.EnableEventTables = types.BoolPointerValue(enableEventTables)
+	s.Collections = fillSelectiveSyncCollections(warehouseItems, managed)
+}
+
+func fillSelectiveSyncCollections(items []api.WarehouseSelectiveSyncItemV1, managed []WarehouseSelectiveSyncCollectionState) []WarehouseSelectiveSyncCollectionState {
+	itemsByCollection := map[string]api.WarehouseSelectiveSyncItemV1{}
+	for _, item := range items {
+		itemsByCollection[item.Collection] = item
+	}
+
+	if managed == nil {
+		managed = []WarehouseSelectiveSyncCollectionState{}
+		for _, item := range items {
+			if !item.Enabled || len(disabledSelectiveSyncProperties(item)) > 0 {
+				managed = append(managed, WarehouseSelectiveSyncCollectionState{Name: types.StringValue(item.Collection)})
+			}
+		}
+	}
+
+	collections := []WarehouseSelectiveSyncCollectionState{}
+	for _, previous := range managed {
+		// Segment only lists the collections it has received data for, so the previous ones are kept until then
+		item, ok := itemsByCollection[previous.Name.ValueString()]
+		if !ok {
+			collections = append(collections, previous)
+
+			continue
+		}
+
+		// Likewise, the disabled properties that Segment has not seen yet are kept
+		disabled := disabledSelectiveSyncProperties(item)
+		for _, property := range previous.DisabledProperties {
+			if _, ok := item.Properties[property.ValueString()]; !ok {
+				disabled = append(disabled, property.ValueString())
+			}
+		}
+		slices.Sort(disabled)
+
+		collection := WarehouseSelectiveSyncCollectionState{
+			Name:               types.StringValue(item.Collection),
+			Enabled:            types.BoolValue(item.Enabled),
+			DisabledProperties: []types.String{},
+		}
+		for _, property := range slices.Compact(disabled) {
+			collection.DisabledProperties = append(collection.DisabledProperties, types.StringValue(property))
+		}
+		collections = append(collections, collection)
+	}
+
+	return collections
+}
+
+// disabledSelectiveSyncProperties returns the sorted properties of a collection that are not synced. Properties are
+// either mapped to whether they are enabled or to an object with an `enabled` field.
+func disabledSelectiveSyncProperties(item api.WarehouseSelectiveSyncItemV1) []string {
+	properties := []string{}
+	for name, value := range item.Properties {
+		enabled := true
+		switch typedValue := value.(type) {
+		case bool:
+			enabled = typedValue
+		case map[string]interface{}:
+			if enabledValue, ok := typedValue["enabled"].(bool); ok {
+				enabled = enabledValue
+			}
+		}
+
+		if !enabled {
+			properties = append(properties, name)
+		}
+	}
+	slices.Sort(properties)
+
+	return properties
+}
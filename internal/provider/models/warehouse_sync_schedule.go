@@ -0,0 +1,76 @@
+package models
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/segmentio/public-api-sdk-go/api"
+)
+
+type WarehouseSyncScheduleState struct {
+	WarehouseID types.String `tfsdk:"warehouse_id"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+	Timezone    types.String `tfsdk:"timezone"`
+	Hours       types.Set    `tfsdk:"hours"`
+}
+
+func (s *WarehouseSyncScheduleState) Fill(enabled bool, schedule *api.AdvancedWarehouseSyncScheduleV1Output) {
+	s.Enabled = types.BoolValue(enabled)
+	s.Timezone = types.StringNull()
+	s.Hours = types.SetNull(types.Int64Type)
+
+	if schedule == nil {
+		return
+	}
+
+	s.Timezone = types.StringPointerValue(schedule.Timezone)
+
+	hours := []int64{}
+	for _, time := range schedule.Times {
+		if time.Enabled {
+			hours = append(hours, int64(time.HourOfDay))
+		}
+	}
+	slices.Sort(hours)
+
+	elements := []attr.Value{}
+	for _, hour := range hours {
+		elements = append(elements, types.Int64Value(hour))
+	}
+	s.Hours = types.SetValueMust(types.Int64Type, elements)
+}
+
+// ToAPIValue returns the schedule with every hour of the day, where only the planned hours are enabled.
+// No schedule is returned when the hours are not known, in which case Segment keeps the previous one.
+func (s *WarehouseSyncScheduleState) ToAPIValue(ctx context.Context) (*api.AdvancedWarehouseSyncScheduleV1Input, diag.Diagnostics) {
+	if s.Hours.IsNull() || s.Hours.IsUnknown() || s.Timezone.IsNull() || s.Timezone.IsUnknown() {
+		return nil, nil
+	}
+
+	var hours []int64
+	diags := s.Hours.ElementsAs(ctx, &hours, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	enabledHours := map[int64]bool{}
+	for _, hour := range hours {
+		enabledHours[hour] = true
+	}
+
+	schedule := api.AdvancedWarehouseSyncScheduleV1Input{
+		Timezone: s.Timezone.ValueString(),
+		Times:    []api.WarehouseAdvancedSyncV1{},
+	}
+	for hour := int64(0); hour < 24; hour++ {
+		schedule.Times = append(schedule.Times, api.WarehouseAdvancedSyncV1{
+			HourOfDay: float32(hour),
+			Enabled:   enabledHours[hour],
+		})
+	}
+
+	return &schedule, diags
+}
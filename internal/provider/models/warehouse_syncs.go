@@ -0,0 +1,54 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/segmentio/public-api-sdk-go/api"
+)
+
+type WarehouseSyncsState struct {
+	WarehouseID types.String               `tfsdk:"warehouse_id"`
+	SourceID    types.String               `tfsdk:"source_id"`
+	Limit       types.Int64                `tfsdk:"limit"`
+	Reports     []WarehouseSyncReportState `tfsdk:"reports"`
+}
+
+type WarehouseSyncReportState struct {
+	SourceID      types.String               `tfsdk:"source_id"`
+	Status        types.String               `tfsdk:"status"`
+	Start         types.String               `tfsdk:"start"`
+	End           types.String               `tfsdk:"end"`
+	Duration      types.Float64              `tfsdk:"duration"`
+	HumanDuration types.String               `tfsdk:"human_duration"`
+	RowsSynced    types.Int64                `tfsdk:"rows_synced"`
+	Notices       []WarehouseSyncNoticeState `tfsdk:"notices"`
+}
+
+type WarehouseSyncNoticeState struct {
+	Level     types.String `tfsdk:"level"`
+	Message   types.String `tfsdk:"message"`
+	CreatedAt types.String `tfsdk:"created_at"`
+}
+
+func (s *WarehouseSyncsState) Fill(reports []api.SyncV1) {
+	s.Reports = []WarehouseSyncReportState{}
+	for _, report := range reports {
+		reportState := WarehouseSyncReportState{
+			SourceID:      types.StringValue(report.SourceId),
+			Status:        types.StringValue(report.Status),
+			Start:         types.StringValue(report.Start),
+			End:           types.StringPointerValue(report.End.Get()),
+			Duration:      types.Float64Value(float64(report.Duration)),
+			HumanDuration: types.StringValue(report.HumanDuration),
+			RowsSynced:    types.Int64Value(int64(report.Count)),
+			Notices:       []WarehouseSyncNoticeState{},
+		}
+		for _, notice := range report.Notices {
+			reportState.Notices = append(reportState.Notices, WarehouseSyncNoticeState{
+				Level:     types.StringValue(notice.Level),
+				Message:   types.StringValue(notice.Message),
+				CreatedAt: types.StringValue(notice.CreatedAt),
+			})
+		}
+		s.Reports = append(s.Reports, reportState)
+	}
+}
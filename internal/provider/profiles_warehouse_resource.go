@@ -53,7 +53,7 @@ func (r *profilesWarehouseResource) Schema(_ context.Context, _ resource.SchemaR
 			},
 			"space_id": schema.StringAttribute{
 				Required:    true,
-				Description: "The Space id.",
+				Description: "The Space id, such as `data.segment_space.example.id`.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -0,0 +1,294 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+
+	"github.com/segmentio/public-api-sdk-go/api"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/docs"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
+)
+
+var (
+	_ resource.Resource                = &profilesWarehouseSelectiveSyncResource{}
+	_ resource.ResourceWithConfigure   = &profilesWarehouseSelectiveSyncResource{}
+	_ resource.ResourceWithImportState = &profilesWarehouseSelectiveSyncResource{}
+)
+
+func NewProfilesWarehouseSelectiveSyncResource() resource.Resource {
+	return &profilesWarehouseSelectiveSyncResource{}
+}
+
+type profilesWarehouseSelectiveSyncResource struct {
+	client      *api.APIClient
+	authContext context.Context
+}
+
+func (r *profilesWarehouseSelectiveSyncResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_profiles_warehouse_selective_sync"
+}
+
+func (r *profilesWarehouseSelectiveSyncResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Configures which Profiles Sync tables and properties of a Space are synced to a Profiles Sync Warehouse. Tables that are not in the configuration are left untouched, and are synced again when they are removed from the configuration or when this resource is destroyed. For more information, visit the [Segment docs](https://segment.com/docs/unify/profiles-sync/profiles-sync-setup/#selective-sync).\n\n" +
+			docs.GenerateImportDocs("<space_id>:<warehouse_id>", "segment_profiles_warehouse_selective_sync"),
+		Attributes: map[string]schema.Attribute{
+			"space_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The id of the Space, such as `data.segment_space.example.id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"warehouse_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The id of the Profiles Sync Warehouse, such as `segment_profiles_warehouse.example.id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enable_event_tables": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the event tables, such as `identifies` and `tracks`, are synced. Selective Sync of the event tables is only supported when this is true. Left untouched when not set.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"collections": selectiveSyncCollectionsSchema("The Profiles Sync tables whose sync is managed by Terraform, such as `identifies`, `tracks`, `profile_traits_updated` or `id_graph_updates`."),
+		},
+	}
+}
+
+func (r *profilesWarehouseSelectiveSyncResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan models.ProfilesWarehouseSelectiveSyncState
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, enableEventTables, _, err := listProfilesWarehouseSelectiveSyncs(r.authContext, r.client, plan.SpaceID.ValueString(), plan.WarehouseID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Selective Sync of Profiles Warehouse (ID: %s) in Space (ID: %s)", plan.WarehouseID.ValueString(), plan.SpaceID.ValueString()),
+			err.Error(),
+		)
+
+		return
+	}
+
+	// Properties that are already disabled are compared against the configuration like on an update
+	var existing models.ProfilesWarehouseSelectiveSyncState
+	existing.Fill(items, enableEventTables, plan.Collections)
+
+	diags = r.updateSelectiveSync(plan, existing.Collections)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.EnableEventTables.IsUnknown() {
+		plan.EnableEventTables = existing.EnableEventTables
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *profilesWarehouseSelectiveSyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var previousState models.ProfilesWarehouseSelectiveSyncState
+	diags := req.State.Get(ctx, &previousState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, enableEventTables, statusCode, err := listProfilesWarehouseSelectiveSyncs(r.authContext, r.client, previousState.SpaceID.ValueString(), previousState.WarehouseID.ValueString())
+	if err != nil {
+		if statusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Selective Sync of Profiles Warehouse (ID: %s) in Space (ID: %s)", previousState.WarehouseID.ValueString(), previousState.SpaceID.ValueString()),
+			err.Error(),
+		)
+
+		return
+	}
+
+	state := models.ProfilesWarehouseSelectiveSyncState{
+		SpaceID:     previousState.SpaceID,
+		WarehouseID: previousState.WarehouseID,
+	}
+	// Imported resources have no collections yet
+	state.Fill(items, enableEventTables, previousState.Collections)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *profilesWarehouseSelectiveSyncResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan models.ProfilesWarehouseSelectiveSyncState
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state models.ProfilesWarehouseSelectiveSyncState
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = r.updateSelectiveSync(plan, state.Collections)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *profilesWarehouseSelectiveSyncResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var config models.ProfilesWarehouseSelectiveSyncState
+	diags := req.State.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Everything that was managed is synced again, while the event tables are left as they are
+	diags = r.updateSelectiveSync(models.ProfilesWarehouseSelectiveSyncState{
+		SpaceID:     config.SpaceID,
+		WarehouseID: config.WarehouseID,
+	}, config.Collections)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *profilesWarehouseSelectiveSyncResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, ":")
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <space_id>:<warehouse_id>. Got: %q", req.ID),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("space_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("warehouse_id"), idParts[1])...)
+}
+
+func (r *profilesWarehouseSelectiveSyncResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected ClientInfo, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = config.client
+	r.authContext = config.authContext
+}
+
+// updateSelectiveSync applies the planned event tables flag and the overrides that turn the previous collections into the planned ones.
+func (r *profilesWarehouseSelectiveSyncResource) updateSelectiveSync(plan models.ProfilesWarehouseSelectiveSyncState, previous []models.WarehouseSelectiveSyncCollectionState) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	input := api.UpdateSelectiveSyncForWarehouseAndSpaceAlphaInput{}
+	if !plan.EnableEventTables.IsNull() && !plan.EnableEventTables.IsUnknown() {
+		input.EnableEventTables = plan.EnableEventTables.ValueBoolPointer()
+	}
+	for _, override := range selectiveSyncOverrides("", plan.Collections, previous) {
+		input.SyncOverrides = append(input.SyncOverrides, api.SpaceWarehouseSchemaOverride{
+			Collection: *override.Collection,
+			Enabled:    override.Enabled,
+			Property:   override.Property,
+		})
+	}
+	if input.EnableEventTables == nil && len(input.SyncOverrides) == 0 {
+		return diags
+	}
+
+	_, body, err := r.client.ProfilesSyncAPI.UpdateSelectiveSyncForWarehouseAndSpace(r.authContext, plan.SpaceID.ValueString(), plan.WarehouseID.ValueString()).UpdateSelectiveSyncForWarehouseAndSpaceAlphaInput(input).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("Unable to update Selective Sync of Profiles Warehouse (ID: %s) in Space (ID: %s)", plan.WarehouseID.ValueString(), plan.SpaceID.ValueString()),
+			getError(err, body),
+		)
+	}
+
+	return diags
+}
+
+func listProfilesWarehouseSelectiveSyncs(authContext context.Context, client *api.APIClient, spaceID string, warehouseID string) ([]api.SpaceWarehouseSelectiveSyncItemAlpha, *bool, int, error) {
+	items := []api.SpaceWarehouseSelectiveSyncItemAlpha{}
+	var enableEventTables *bool
+	paginationInput := *api.NewPaginationInput(MaxPageSize)
+
+	for {
+		out, body, err := client.ProfilesSyncAPI.ListSelectiveSyncsFromWarehouseAndSpace(authContext, spaceID, warehouseID).Pagination(paginationInput).Execute()
+		if body != nil {
+			defer body.Body.Close()
+		}
+		if err != nil {
+			statusCode := 0
+			if body != nil {
+				statusCode = body.StatusCode
+			}
+
+			return nil, nil, statusCode, errors.New(getError(err, body))
+		}
+
+		items = append(items, out.Data.GetItems()...)
+		if out.Data.EnableEventTables != nil {
+			enableEventTables = out.Data.EnableEventTables
+		}
+
+		next := out.Data.GetPagination().Next.Get()
+		if next == nil {
+			return items, enableEventTables, 0, nil
+		}
+		paginationInput.SetCursor(*next)
+	}
+}
@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/segmentio/public-api-sdk-go/api"
+)
+
+func TestAccProfilesWarehouseSelectiveSyncResource(t *testing.T) {
+	t.Parallel()
+
+	enableEventTables := false
+	items := []api.SpaceWarehouseSelectiveSyncItemAlpha{
+		{
+			WarehouseId: "my-warehouse-id",
+			Collection:  "identifies",
+			Enabled:     true,
+			Properties:  map[string]interface{}{"context_ip": true, "email": true},
+		},
+		{
+			WarehouseId: "my-warehouse-id",
+			Collection:  "page",
+			Enabled:     true,
+			Properties:  map[string]interface{}{"url": true},
+		},
+	}
+
+	fakeServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("content-type", "application/json")
+
+			var payload map[string]interface{}
+			if req.URL.Path == "/spaces/my-space-id/profiles-warehouses/my-warehouse-id/selective-syncs" && req.Method == http.MethodGet {
+				payload = map[string]interface{}{"items": items, "enableEventTables": enableEventTables, "pagination": map[string]interface{}{"current": "MA=="}}
+			} else if req.URL.Path == "/spaces/my-space-id/profiles-warehouses/my-warehouse-id/selective-syncs" && req.Method == http.MethodPatch {
+				var input api.UpdateSelectiveSyncForWarehouseAndSpaceAlphaInput
+				_ = json.NewDecoder(req.Body).Decode(&input)
+
+				if input.EnableEventTables != nil {
+					enableEventTables = *input.EnableEventTables
+				}
+				for _, override := range input.SyncOverrides {
+					for i, item := range items {
+						if item.Collection != override.Collection {
+							continue
+						}
+
+						if override.Property == nil {
+							items[i].Enabled = override.Enabled
+						} else {
+							items[i].Properties[*override.Property] = override.Enabled
+						}
+					}
+				}
+				payload = map[string]interface{}{"status": "SUCCESS"}
+			}
+
+			out, _ := json.Marshal(map[string]interface{}{"data": payload})
+			_, _ = w.Write(out)
+		}),
+	)
+	defer fakeServer.Close()
+
+	providerConfig := `
+		provider "segment" {
+			url   = "` + fakeServer.URL + `"
+			token = "abc123"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+					resource "segment_profiles_warehouse_selective_sync" "test" {
+						space_id            = "my-space-id"
+						warehouse_id        = "my-warehouse-id"
+						enable_event_tables = true
+						collections = [
+							{
+								name                = "identifies"
+								disabled_properties = ["context_ip"]
+							},
+							{
+								name    = "page"
+								enabled = false
+							},
+						]
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_profiles_warehouse_selective_sync.test", "enable_event_tables", "true"),
+					resource.TestCheckResourceAttr("segment_profiles_warehouse_selective_sync.test", "collections.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("segment_profiles_warehouse_selective_sync.test", "collections.*", map[string]string{
+						"name":                  "identifies",
+						"enabled":               "true",
+						"disabled_properties.#": "1",
+						"disabled_properties.0": "context_ip",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("segment_profiles_warehouse_selective_sync.test", "collections.*", map[string]string{
+						"name":                  "page",
+						"enabled":               "false",
+						"disabled_properties.#": "0",
+					}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "segment_profiles_warehouse_selective_sync.test",
+				ImportState:                          true,
+				ImportStateId:                        "my-space-id:my-warehouse-id",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "warehouse_id",
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+					resource "segment_profiles_warehouse_selective_sync" "test" {
+						space_id     = "my-space-id"
+						warehouse_id = "my-warehouse-id"
+						collections = [
+							{
+								name                = "identifies"
+								disabled_properties = ["email"]
+							},
+						]
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_profiles_warehouse_selective_sync.test", "enable_event_tables", "true"),
+					resource.TestCheckResourceAttr("segment_profiles_warehouse_selective_sync.test", "collections.#", "1"),
+					resource.TestCheckResourceAttr("segment_profiles_warehouse_selective_sync.test", "collections.0.name", "identifies"),
+					resource.TestCheckResourceAttr("segment_profiles_warehouse_selective_sync.test", "collections.0.disabled_properties.#", "1"),
+					resource.TestCheckResourceAttr("segment_profiles_warehouse_selective_sync.test", "collections.0.disabled_properties.0", "email"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -18,7 +19,10 @@ import (
 )
 
 // Ensure segmentProvider satisfies various provider interfaces.
-var _ provider.Provider = &segmentProvider{}
+var (
+	_ provider.Provider              = &segmentProvider{}
+	_ provider.ProviderWithFunctions = &segmentProvider{}
+)
 
 // segmentProvider defines the provider implementation.
 type segmentProvider struct {
@@ -31,6 +35,8 @@ type segmentProvider struct {
 type ClientInfo struct {
 	client      *api.APIClient
 	authContext context.Context
+	sourceLocks *keyedMutex
+	catalog     *catalogCache
 }
 
 // segmentProviderModel describes the provider data model.
@@ -138,6 +144,8 @@ func (p *segmentProvider) Configure(ctx context.Context, req provider.ConfigureR
 	clientInfo := &ClientInfo{
 		client:      client,
 		authContext: auth,
+		sourceLocks: &keyedMutex{},
+		catalog:     &catalogCache{},
 	}
 
 	resp.DataSourceData = clientInfo
@@ -148,9 +156,15 @@ func (p *segmentProvider) Resources(_ context.Context) []func() resource.Resourc
 	return []func() resource.Resource{
 		NewLabelResource,
 		NewDestinationResource,
+		NewDestinationCloneResource,
 		NewSourceResource,
 		NewWarehouseResource,
 		NewSourceWarehouseConnectionResource,
+		NewWarehouseSelectiveSyncResource,
+		NewWarehouseSyncScheduleResource,
+		NewProfilesWarehouseSelectiveSyncResource,
+		NewAudienceResource,
+		NewComputedTraitResource,
 		NewTrackingPlanResource,
 		NewUserResource,
 		NewUserGroupResource,
@@ -158,7 +172,10 @@ func (p *segmentProvider) Resources(_ context.Context) []func() resource.Resourc
 		NewDestinationFilterResource,
 		NewProfilesWarehouseResource,
 		NewDestinationSubscriptionResource,
+		NewDestinationSubscriptionsResource,
 		NewSourceTrackingPlanConnectionResource,
+		NewSourceSchemaSettingsResource,
+		NewSourceWriteKeyResource,
 		NewReverseETLModelResource,
 		NewTransformationResource,
 		NewInsertFunctionInstanceResource,
@@ -171,15 +188,31 @@ func (p *segmentProvider) DataSources(_ context.Context) []func() datasource.Dat
 		NewSourceDataSource,
 		NewSourceMetadataDataSource,
 		NewDestinationMetadataDataSource,
+		NewDestinationPresetsDataSource,
+		NewDestinationChildrenDataSource,
 		NewWarehouseMetadataDataSource,
 		NewDestinationDataSource,
 		NewWarehouseDataSource,
+		NewWarehouseConnectionTestDataSource,
+		NewWarehouseSyncsDataSource,
+		NewSpaceDataSource,
+		NewSpacesDataSource,
+		NewAudienceDataSource,
 		NewTrackingPlanDataSource,
+		NewTrackingPlanFQLDataSource,
 		NewRoleDataSource,
 		NewUserDataSource,
 	}
 }
 
+func (p *segmentProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewRulesFromTypewriterFunction,
+		NewRulesFromProtocolsJSONFunction,
+		NewRulesFromProtocolsCSVFunction,
+	}
+}
+
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &segmentProvider{
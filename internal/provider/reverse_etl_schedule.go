@@ -0,0 +1,377 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/robfig/cron/v3"
+	"github.com/segmentio/public-api-sdk-go/api"
+
+	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
+)
+
+// ReverseETLScheduleMinCronInterval is the shortest time Segment allows between two runs of a CRON schedule.
+const ReverseETLScheduleMinCronInterval = 15 * time.Minute
+
+var reverseETLScheduleIntervals = []string{"15m", "30m", "1h", "2h", "4h", "6h", "8h", "12h", "1d"}
+
+var reverseETLScheduleBlocks = map[string]string{
+	models.ReverseETLScheduleStrategyPeriodic:     "periodic",
+	models.ReverseETLScheduleStrategySpecificDays: "specific_days",
+	models.ReverseETLScheduleStrategyCron:         "cron",
+	models.ReverseETLScheduleStrategyDbtCloud:     "dbt_cloud",
+}
+
+// reverseETLScheduleSchema returns the schedule of a subscription attached to a Reverse ETL model, shared by the subscription resources.
+func reverseETLScheduleSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:    true,
+		Description: "(Reverse ETL only) The schedule for the subscription being attached to ReverseETL model. The schedule is configured with the block matching the strategy, or with `config`.",
+		Validators: []validator.Object{
+			reverseETLScheduleValidator{},
+		},
+		Attributes: map[string]schema.Attribute{
+			"strategy": schema.StringAttribute{
+				Required:    true,
+				Description: "Strategy supports the following modes: PERIODIC, SPECIFIC_DAYS, CRON, DBT_CLOUD or MANUAL.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						models.ReverseETLScheduleStrategyPeriodic,
+						models.ReverseETLScheduleStrategySpecificDays,
+						models.ReverseETLScheduleStrategyCron,
+						models.ReverseETLScheduleStrategyDbtCloud,
+						models.ReverseETLScheduleStrategyManual,
+					),
+				},
+			},
+			"config": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Configures the schedule for the subscription as JSON. Prefer the block matching the strategy, which is validated before being sent to Segment.",
+				CustomType:  jsontypes.NormalizedType{},
+			},
+			"periodic": schema.SingleNestedAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The configuration of the PERIODIC strategy.",
+				Attributes: map[string]schema.Attribute{
+					"interval": schema.StringAttribute{
+						Required:    true,
+						Description: "The time between two runs, among " + strings.Join(reverseETLScheduleIntervals, ", ") + ".",
+						Validators: []validator.String{
+							stringvalidator.OneOf(reverseETLScheduleIntervals...),
+						},
+					},
+				},
+			},
+			"specific_days": schema.SingleNestedAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The configuration of the SPECIFIC_DAYS strategy, which runs at every configured hour of every configured day.",
+				Attributes: map[string]schema.Attribute{
+					"days": schema.SetAttribute{
+						Required:    true,
+						ElementType: types.Int64Type,
+						Description: "The days of the week, between 0 (Sunday) and 6 (Saturday).",
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueInt64sAre(int64validator.Between(0, 6)),
+						},
+					},
+					"hours": schema.SetAttribute{
+						Required:    true,
+						ElementType: types.Int64Type,
+						Description: "The hours of the day, between 0 and 23.",
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueInt64sAre(int64validator.Between(0, 23)),
+						},
+					},
+					"timezone": schema.StringAttribute{
+						Required:    true,
+						Description: "The TZ database timezone of the hours, such as `America/New_York`.",
+						Validators: []validator.String{
+							timezoneValidator{},
+						},
+					},
+				},
+			},
+			"cron": schema.SingleNestedAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The configuration of the CRON strategy.",
+				Attributes: map[string]schema.Attribute{
+					"spec": schema.StringAttribute{
+						Required:    true,
+						Description: "The standard 5 fields cron expression, such as `0 */6 * * *`. Runs must be at least 15 minutes apart.",
+						Validators: []validator.String{
+							cronSpecValidator{},
+						},
+					},
+					"timezone": schema.StringAttribute{
+						Required:    true,
+						Description: "The TZ database timezone of the cron expression, such as `America/New_York`.",
+						Validators: []validator.String{
+							timezoneValidator{},
+						},
+					},
+				},
+			},
+			"dbt_cloud": schema.SingleNestedAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The configuration of the DBT_CLOUD strategy, which runs after each run of a dbt Cloud job.",
+				Attributes: map[string]schema.Attribute{
+					"job_id": schema.StringAttribute{
+						Required:    true,
+						Description: "The id of the dbt Cloud job.",
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(regexp.MustCompile(`^[0-9]+$`), "must be a dbt Cloud job id"),
+						},
+					},
+					"account_id": schema.StringAttribute{
+						Required:    true,
+						Description: "The id of the dbt Cloud account of the job.",
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(regexp.MustCompile(`^[0-9]+$`), "must be a dbt Cloud account id"),
+						},
+					},
+				},
+			},
+			"next_runs": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The times of the next runs of CRON and SPECIFIC_DAYS schedules, as of when the schedule was last applied. They are not updated by refreshes while the schedule is unchanged.",
+			},
+		},
+	}
+}
+
+// reverseETLScheduleDataSourceSchema returns the read-only counterpart of reverseETLScheduleSchema.
+func reverseETLScheduleDataSourceSchema() datasourceschema.SingleNestedAttribute {
+	return datasourceschema.SingleNestedAttribute{
+		Computed:    true,
+		Description: "(Reverse ETL only) The schedule for the subscription being attached to ReverseETL model.",
+		Attributes: map[string]datasourceschema.Attribute{
+			"strategy": datasourceschema.StringAttribute{
+				Computed:    true,
+				Description: "Strategy supports the following modes: PERIODIC, SPECIFIC_DAYS, CRON, DBT_CLOUD or MANUAL.",
+			},
+			"config": datasourceschema.StringAttribute{
+				Computed:    true,
+				Description: "Configures the schedule for the subscription as JSON.",
+				CustomType:  jsontypes.NormalizedType{},
+			},
+			"periodic": datasourceschema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "The configuration of the PERIODIC strategy.",
+				Attributes: map[string]datasourceschema.Attribute{
+					"interval": datasourceschema.StringAttribute{
+						Computed:    true,
+						Description: "The time between two runs.",
+					},
+				},
+			},
+			"specific_days": datasourceschema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "The configuration of the SPECIFIC_DAYS strategy, which runs at every configured hour of every configured day.",
+				Attributes: map[string]datasourceschema.Attribute{
+					"days": datasourceschema.SetAttribute{
+						Computed:    true,
+						ElementType: types.Int64Type,
+						Description: "The days of the week, between 0 (Sunday) and 6 (Saturday).",
+					},
+					"hours": datasourceschema.SetAttribute{
+						Computed:    true,
+						ElementType: types.Int64Type,
+						Description: "The hours of the day, between 0 and 23.",
+					},
+					"timezone": datasourceschema.StringAttribute{
+						Computed:    true,
+						Description: "The TZ database timezone of the hours.",
+					},
+				},
+			},
+			"cron": datasourceschema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "The configuration of the CRON strategy.",
+				Attributes: map[string]datasourceschema.Attribute{
+					"spec": datasourceschema.StringAttribute{
+						Computed:    true,
+						Description: "The cron expression.",
+					},
+					"timezone": datasourceschema.StringAttribute{
+						Computed:    true,
+						Description: "The TZ database timezone of the cron expression.",
+					},
+				},
+			},
+			"dbt_cloud": datasourceschema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "The configuration of the DBT_CLOUD strategy, which runs after each run of a dbt Cloud job.",
+				Attributes: map[string]datasourceschema.Attribute{
+					"job_id": datasourceschema.StringAttribute{
+						Computed:    true,
+						Description: "The id of the dbt Cloud job.",
+					},
+					"account_id": datasourceschema.StringAttribute{
+						Computed:    true,
+						Description: "The id of the dbt Cloud account of the job.",
+					},
+				},
+			},
+			"next_runs": datasourceschema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The times of the next runs of CRON and SPECIFIC_DAYS schedules.",
+			},
+		},
+	}
+}
+
+func getSchedule(ctx context.Context, planSchedule basetypes.ObjectValue) (*api.ReverseEtlScheduleDefinition, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if planSchedule.IsNull() || planSchedule.IsUnknown() {
+		return nil, diags
+	}
+
+	// The blocks which are not configured are unknown until Segment returns them
+	var schedule models.ReverseETLScheduleState
+	diags.Append(planSchedule.As(ctx, &schedule, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return schedule.ToAPIValue()
+}
+
+// reverseETLScheduleValidator checks that a schedule is configured with the block of its strategy, or with its JSON config.
+type reverseETLScheduleValidator struct{}
+
+func (v reverseETLScheduleValidator) Description(_ context.Context) string {
+	return "the schedule must be configured with either the block matching its strategy or config, and no other block"
+}
+
+func (v reverseETLScheduleValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v reverseETLScheduleValidator) ValidateObject(_ context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	attributes := req.ConfigValue.Attributes()
+	strategy, ok := attributes["strategy"].(types.String)
+	if !ok || strategy.IsUnknown() {
+		return
+	}
+
+	expectedBlock := reverseETLScheduleBlocks[strategy.ValueString()]
+	for _, block := range reverseETLScheduleBlocks {
+		if block == expectedBlock || attributes[block] == nil || attributes[block].IsNull() {
+			continue
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			req.Path.AtName(block),
+			"Invalid reverse ETL schedule",
+			fmt.Sprintf("%q cannot be set with the %s strategy.", block, strategy.ValueString()),
+		)
+	}
+
+	config := attributes["config"]
+	configured := config != nil && !config.IsNull()
+	if expectedBlock == "" {
+		return
+	}
+
+	if attributes[expectedBlock] != nil && !attributes[expectedBlock].IsNull() {
+		if configured {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtName("config"),
+				"Invalid reverse ETL schedule",
+				fmt.Sprintf("\"config\" cannot be set along with %q.", expectedBlock),
+			)
+		}
+
+		return
+	}
+
+	if !configured {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid reverse ETL schedule",
+			fmt.Sprintf("The %s strategy requires either %q or \"config\" to be set.", strategy.ValueString(), expectedBlock),
+		)
+	}
+}
+
+// cronSpecValidator checks that a string is a standard cron expression whose runs are far enough apart for Segment.
+type cronSpecValidator struct{}
+
+func (v cronSpecValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be a standard 5 fields cron expression with runs at least %s apart", ReverseETLScheduleMinCronInterval)
+}
+
+func (v cronSpecValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cronSpecValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := validateCronSpec(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid cron expression",
+			fmt.Sprintf("%q is not a valid cron expression: %s.", req.ConfigValue.ValueString(), err.Error()),
+		)
+	}
+}
+
+func validateCronSpec(spec string) error {
+	// Descriptors such as @daily and timezone prefixes are accepted by the parser but not by Segment
+	if len(strings.Fields(spec)) != 5 {
+		return fmt.Errorf("expected 5 fields (minute, hour, day of month, month and day of week)")
+	}
+
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return err
+	}
+
+	// A year of runs covers every combination of the fields, and runs can be at most a minute apart
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	previous := schedule.Next(start)
+	if previous.IsZero() {
+		return fmt.Errorf("the expression never runs")
+	}
+	for previous.Before(start.AddDate(1, 0, 0)) {
+		next := schedule.Next(previous)
+		if next.IsZero() {
+			break
+		}
+		if next.Sub(previous) < ReverseETLScheduleMinCronInterval {
+			return fmt.Errorf("runs at %s and %s are less than %s apart", previous.Format("Mon 15:04"), next.Format("Mon 15:04"), ReverseETLScheduleMinCronInterval)
+		}
+		previous = next
+	}
+
+	return nil
+}
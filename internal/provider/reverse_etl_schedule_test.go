@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/segmentio/public-api-sdk-go/api"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
+)
+
+func TestValidateCronSpec(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		assert.NoError(t, validateCronSpec("0 */6 * * *"))
+		assert.NoError(t, validateCronSpec("*/15 * * * 1-5"))
+		assert.NoError(t, validateCronSpec("0,30 9 1 * *"))
+	})
+
+	t.Run("invalid syntax", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Error(t, validateCronSpec("0 */6 * *"))
+		assert.Error(t, validateCronSpec("0 0 */6 * * *"))
+		assert.Error(t, validateCronSpec("@daily"))
+		assert.Error(t, validateCronSpec("61 * * * *"))
+	})
+
+	t.Run("runs too close", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Error(t, validateCronSpec("* * * * *"))
+		assert.Error(t, validateCronSpec("*/5 * * * *"))
+		assert.Error(t, validateCronSpec("0,10 * * * *"))
+		// The last run of an hour is close to the first run of the next one
+		assert.Error(t, validateCronSpec("0,50 * * * *"))
+	})
+}
+
+func TestReverseETLScheduleNextRuns(t *testing.T) {
+	t.Parallel()
+
+	// Monday
+	now := time.Date(2024, time.January, 1, 10, 30, 0, 0, time.UTC)
+
+	t.Run("cron", func(t *testing.T) {
+		t.Parallel()
+		runs := models.ReverseETLScheduleNextRuns(models.ReverseETLScheduleStrategyCron, api.Config{
+			ReverseEtlCronScheduleConfig: &api.ReverseEtlCronScheduleConfig{Spec: "0 */6 * * *", Timezone: "America/New_York"},
+		}, now)
+
+		assert.Len(t, runs, models.ReverseETLScheduleNextRunsCount)
+		assert.Equal(t, "2024-01-01T06:00:00-05:00", runs[0].ValueString())
+		assert.Equal(t, "2024-01-01T12:00:00-05:00", runs[1].ValueString())
+		assert.Equal(t, "2024-01-02T00:00:00-05:00", runs[3].ValueString())
+	})
+
+	t.Run("specific days", func(t *testing.T) {
+		t.Parallel()
+		runs := models.ReverseETLScheduleNextRuns(models.ReverseETLScheduleStrategySpecificDays, api.Config{
+			ReverseEtlSpecificTimeScheduleConfig: &api.ReverseEtlSpecificTimeScheduleConfig{Days: []float32{0, 1}, Hours: []float32{8, 18}, Timezone: "UTC"},
+		}, now)
+
+		assert.Equal(t, []string{
+			"2024-01-01T18:00:00Z",
+			"2024-01-07T08:00:00Z",
+			"2024-01-07T18:00:00Z",
+			"2024-01-08T08:00:00Z",
+			"2024-01-08T18:00:00Z",
+		}, []string{runs[0].ValueString(), runs[1].ValueString(), runs[2].ValueString(), runs[3].ValueString(), runs[4].ValueString()})
+	})
+
+	t.Run("single day and hour", func(t *testing.T) {
+		t.Parallel()
+		runs := models.ReverseETLScheduleNextRuns(models.ReverseETLScheduleStrategySpecificDays, api.Config{
+			ReverseEtlSpecificTimeScheduleConfig: &api.ReverseEtlSpecificTimeScheduleConfig{Days: []float32{1}, Hours: []float32{8}, Timezone: "UTC"},
+		}, now)
+
+		assert.Len(t, runs, models.ReverseETLScheduleNextRunsCount)
+		assert.Equal(t, "2024-01-08T08:00:00Z", runs[0].ValueString())
+		assert.Equal(t, "2024-02-05T08:00:00Z", runs[4].ValueString())
+	})
+
+	t.Run("other strategies", func(t *testing.T) {
+		t.Parallel()
+		runs := models.ReverseETLScheduleNextRuns(models.ReverseETLScheduleStrategyPeriodic, api.Config{
+			ReverseEtlPeriodicScheduleConfig: &api.ReverseEtlPeriodicScheduleConfig{Interval: "1d"},
+		}, now)
+
+		assert.Nil(t, runs)
+	})
+}
+
+func TestReverseETLScheduleKeepNextRuns(t *testing.T) {
+	t.Parallel()
+
+	previous := &models.ReverseETLScheduleState{
+		Strategy: types.StringValue(models.ReverseETLScheduleStrategyCron),
+		Config:   jsontypes.NewNormalizedValue(`{"spec":"0 */6 * * *","timezone":"UTC"}`),
+		NextRuns: []types.String{types.StringValue("2024-01-01T12:00:00Z")},
+	}
+
+	t.Run("unchanged schedule", func(t *testing.T) {
+		t.Parallel()
+		schedule := &models.ReverseETLScheduleState{
+			Strategy: types.StringValue(models.ReverseETLScheduleStrategyCron),
+			Config:   jsontypes.NewNormalizedValue(`{"timezone": "UTC", "spec": "0 */6 * * *"}`),
+			NextRuns: []types.String{types.StringValue("2024-01-02T12:00:00Z")},
+		}
+		schedule.KeepNextRuns(context.Background(), previous)
+
+		assert.Equal(t, previous.NextRuns, schedule.NextRuns)
+	})
+
+	t.Run("changed schedule", func(t *testing.T) {
+		t.Parallel()
+		schedule := &models.ReverseETLScheduleState{
+			Strategy: types.StringValue(models.ReverseETLScheduleStrategyCron),
+			Config:   jsontypes.NewNormalizedValue(`{"spec":"0 */12 * * *","timezone":"UTC"}`),
+			NextRuns: []types.String{types.StringValue("2024-01-02T12:00:00Z")},
+		}
+		schedule.KeepNextRuns(context.Background(), previous)
+
+		assert.Equal(t, "2024-01-02T12:00:00Z", schedule.NextRuns[0].ValueString())
+	})
+
+	t.Run("no schedule", func(t *testing.T) {
+		t.Parallel()
+		var schedule *models.ReverseETLScheduleState
+		schedule.KeepNextRuns(context.Background(), previous)
+
+		assert.Nil(t, schedule)
+	})
+}
+
+func TestReverseETLScheduleToAPIValue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("typed config", func(t *testing.T) {
+		t.Parallel()
+		schedule := models.ReverseETLScheduleState{
+			Strategy: types.StringValue(models.ReverseETLScheduleStrategyDbtCloud),
+			DbtCloud: &models.ReverseETLDbtCloudScheduleState{JobID: types.StringValue("123"), AccountID: types.StringValue("456")},
+		}
+		out, diags := schedule.ToAPIValue()
+
+		assert.False(t, diags.HasError())
+		assert.Equal(t, "DBT_CLOUD", out.Strategy)
+		assert.Equal(t, &api.ReverseEtlDbtCloudScheduleConfig{JobId: "123", AccountId: "456"}, out.Config.Get().ReverseEtlDbtCloudScheduleConfig)
+	})
+
+	t.Run("JSON config", func(t *testing.T) {
+		t.Parallel()
+		schedule := models.ReverseETLScheduleState{
+			Strategy: types.StringValue(models.ReverseETLScheduleStrategyPeriodic),
+			Config:   jsontypes.NewNormalizedValue(`{"interval":"1d"}`),
+		}
+		out, diags := schedule.ToAPIValue()
+
+		assert.False(t, diags.HasError())
+		assert.Equal(t, "1d", out.Config.Get().ReverseEtlPeriodicScheduleConfig.Interval)
+	})
+
+	t.Run("manual", func(t *testing.T) {
+		t.Parallel()
+		schedule := models.ReverseETLScheduleState{
+			Strategy: types.StringValue(models.ReverseETLScheduleStrategyManual),
+			Config:   jsontypes.NewNormalizedUnknown(),
+		}
+		out, diags := schedule.ToAPIValue()
+
+		assert.False(t, diags.HasError())
+		assert.True(t, out.Config.IsSet())
+		assert.Nil(t, out.Config.Get())
+	})
+}
+
+func TestReverseETLScheduleValidator(t *testing.T) {
+	t.Parallel()
+
+	blockType := types.ObjectType{AttrTypes: map[string]attr.Type{"interval": types.StringType}}
+	schedule := func(strategy string, config jsontypes.Normalized, periodic types.Object) types.Object {
+		return types.ObjectValueMust(
+			map[string]attr.Type{"strategy": types.StringType, "config": jsontypes.NormalizedType{}, "periodic": blockType},
+			map[string]attr.Value{"strategy": types.StringValue(strategy), "config": config, "periodic": periodic},
+		)
+	}
+	periodic := types.ObjectValueMust(blockType.AttrTypes, map[string]attr.Value{"interval": types.StringValue("1d")})
+	validate := func(value types.Object) bool {
+		resp := validator.ObjectResponse{}
+		reverseETLScheduleValidator{}.ValidateObject(context.Background(), validator.ObjectRequest{Path: path.Root("reverse_etl_schedule"), ConfigValue: value}, &resp)
+
+		return resp.Diagnostics.HasError()
+	}
+
+	assert.False(t, validate(schedule("PERIODIC", jsontypes.NewNormalizedNull(), periodic)))
+	assert.False(t, validate(schedule("PERIODIC", jsontypes.NewNormalizedValue(`{"interval":"1d"}`), types.ObjectNull(blockType.AttrTypes))))
+	assert.False(t, validate(schedule("MANUAL", jsontypes.NewNormalizedNull(), types.ObjectNull(blockType.AttrTypes))))
+	assert.True(t, validate(schedule("PERIODIC", jsontypes.NewNormalizedNull(), types.ObjectNull(blockType.AttrTypes))))
+	assert.True(t, validate(schedule("PERIODIC", jsontypes.NewNormalizedValue(`{"interval":"1d"}`), periodic)))
+	assert.True(t, validate(schedule("CRON", jsontypes.NewNormalizedNull(), periodic)))
+	assert.True(t, validate(schedule("MANUAL", jsontypes.NewNormalizedNull(), periodic)))
+}
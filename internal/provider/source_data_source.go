@@ -164,6 +164,7 @@ func (d *sourceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 			},
 			"write_keys": schema.ListAttribute{
 				Computed:    true,
+				Sensitive:   true,
 				ElementType: types.StringType,
 				Description: "The write keys used to send data from the Source. This field is left empty when the current token does not have the 'source admin' permission.",
 			},
@@ -10,8 +10,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/segmentio/public-api-sdk-go/api"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -29,6 +32,7 @@ func NewSourceMetadataDataSource() datasource.DataSource {
 type sourceMetadataDataSource struct {
 	client      *api.APIClient
 	authContext context.Context
+	catalog     *catalogCache
 }
 
 // Metadata returns the data source type name.
@@ -47,6 +51,20 @@ func (d *sourceMetadataDataSource) Read(ctx context.Context, req datasource.Read
 		return
 	}
 
+	if state.ID.IsNull() {
+		id, err := findSourceMetadataID(d.authContext, d.client, d.catalog, state.Slug.ValueString(), "")
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("slug"),
+				"Unable to find Source metadata",
+				err.Error(),
+			)
+
+			return
+		}
+		state.ID = types.StringValue(id)
+	}
+
 	id := state.ID.ValueString()
 	if id == "" {
 		resp.Diagnostics.AddError("Unable to read Source Metadata", "ID is empty")
@@ -171,9 +189,24 @@ func sourceMetadataSchema() map[string]schema.Attribute {
 
 // Schema defines the schema for the data source.
 func (d *sourceMetadataDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := sourceMetadataSchema()
+	attributes["id"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The id for this Source metadata in the Segment catalog. Config API note: analogous to `name`. Exactly one of `id` or `slug` must be set.",
+		Validators: []validator.String{
+			stringvalidator.ExactlyOneOf(path.MatchRoot("slug")),
+		},
+	}
+	attributes["slug"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The slug that identifies this Source in the Segment app. Config API note: equal to `name`. Can be set instead of `id` to look up the Source metadata in the Segment catalog.",
+	}
+
 	resp.Schema = schema.Schema{
 		Description: "Reads a Source metadata. For more information, visit the [Segment docs](https://segment.com/docs/connections/sources/catalog/).",
-		Attributes:  sourceMetadataSchema(),
+		Attributes:  attributes,
 	}
 }
 
@@ -195,4 +228,5 @@ func (d *sourceMetadataDataSource) Configure(_ context.Context, req datasource.C
 
 	d.client = clientInfo.client
 	d.authContext = clientInfo.authContext
+	d.catalog = clientInfo.catalog
 }
@@ -3,6 +3,7 @@ package provider
 import (
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -159,4 +160,86 @@ func TestAccSourceMetadataDataSource(t *testing.T) {
 			},
 		})
 	})
+	t.Run("slug lookup", func(t *testing.T) {
+		t.Parallel()
+		fakeServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.Header().Set("content-type", "application/json")
+
+				metadata := `
+					{
+						"id": "my-source-metadata-id",
+						"slug": "my-source-metadata-slug",
+						"name": "The name of the source metadata",
+						"categories": [],
+						"description": "A description of a source metadata.",
+						"logos": {
+							"default": "default logo"
+						},
+						"options": [],
+						"isCloudEventSource": false
+					}
+				`
+				if req.URL.Path == "/catalog/sources" {
+					_, _ = w.Write([]byte(`
+						{
+							"data": {
+								"sourcesCatalog": [
+									{
+										"id": "my-other-source-metadata-id",
+										"slug": "my-other-source-metadata-slug",
+										"name": "The name of the other source metadata",
+										"categories": [],
+										"description": "A description of another source metadata.",
+										"logos": {
+											"default": "default logo"
+										},
+										"options": [],
+										"isCloudEventSource": false
+									},
+									` + metadata + `
+								],
+								"pagination": {
+									"current": "MA==",
+									"totalEntries": 2
+								}
+							}
+						}
+					`))
+
+					return
+				}
+
+				_, _ = w.Write([]byte(`{"data": {"sourceMetadata": ` + metadata + `}}`))
+			}),
+		)
+		defer fakeServer.Close()
+
+		providerConfig := `
+			provider "segment" {
+				url   = "` + fakeServer.URL + `"
+				token = "abc123"
+			}
+		`
+
+		resource.Test(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				// Read testing
+				{
+					Config: providerConfig + `data "segment_source_metadata" "test" { slug = "my-source-metadata-slug" }`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.segment_source_metadata.test", "id", "my-source-metadata-id"),
+						resource.TestCheckResourceAttr("data.segment_source_metadata.test", "slug", "my-source-metadata-slug"),
+						resource.TestCheckResourceAttr("data.segment_source_metadata.test", "name", "The name of the source metadata"),
+					),
+				},
+				// Unknown slugs are reported
+				{
+					Config:      providerConfig + `data "segment_source_metadata" "test" { slug = "my-unknown-slug" }`,
+					ExpectError: regexp.MustCompile(`no Source with slug "my-unknown-slug"`),
+				},
+			},
+		})
+	})
 }
@@ -2,10 +2,13 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -28,6 +31,7 @@ var (
 	_ resource.Resource                = &sourceResource{}
 	_ resource.ResourceWithConfigure   = &sourceResource{}
 	_ resource.ResourceWithImportState = &sourceResource{}
+	_ resource.ResourceWithModifyPlan  = &sourceResource{}
 )
 
 func NewSourceResource() resource.Resource {
@@ -37,6 +41,7 @@ func NewSourceResource() resource.Resource {
 type sourceResource struct {
 	client      *api.APIClient
 	authContext context.Context
+	catalog     *catalogCache
 }
 
 func (r *sourceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -67,30 +72,41 @@ func (r *sourceResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"function_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The id of a Source Function to create this Source from, for example `segment_function.example.id`. Exactly one of `function_id` or `metadata` must be set. The Source metadata is resolved from the catalog id of the Function, and `settings` is validated against the settings declared by the Function. The Source is replaced when the catalog id changes.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("metadata")),
+				},
+			},
 			"metadata": schema.SingleNestedAttribute{
-				Description: "The metadata for the Source.",
-				Required:    true,
+				Description: "The metadata for the Source. Exactly one of `metadata` or `function_id` must be set.",
+				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
 					objectplanmodifier.UseStateForUnknown(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"id": schema.StringAttribute{
-						Required: true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
+						Optional:    true,
+						Computed:    true,
+						Description: "The id for this Source metadata in the Segment catalog. Exactly one of `id`, `slug` or `name` must be set. The Source is only replaced when the resolved id changes.",
+						Validators: []validator.String{
+							stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("slug"), path.MatchRelative().AtParent().AtName("name")),
 						},
-						Description: "The id for this Source metadata in the Segment catalog.",
 					},
 					"name": schema.StringAttribute{
+						Optional:    true,
 						Computed:    true,
-						Description: "The user-friendly name of this Source.",
+						Description: "The user-friendly name of this Source. Can be set instead of `id` to look up the Source metadata in the Segment catalog.",
 						PlanModifiers: []planmodifier.String{
 							stringplanmodifier.UseStateForUnknown(),
 						},
 					},
 					"slug": schema.StringAttribute{
+						Optional:    true,
 						Computed:    true,
-						Description: "The slug that identifies this Source in the Segment app.",
+						Description: "The slug that identifies this Source in the Segment app. Can be set instead of `id` to look up the Source metadata in the Segment catalog.",
 						PlanModifiers: []planmodifier.String{
 							stringplanmodifier.UseStateForUnknown(),
 						},
@@ -212,12 +228,36 @@ func (r *sourceResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 			},
 			"write_keys": schema.ListAttribute{
 				Computed:    true,
+				Sensitive:   true,
 				ElementType: types.StringType,
 				PlanModifiers: []planmodifier.List{
 					listplanmodifier.UseStateForUnknown(),
 				},
 				Description: "The write keys used to send data from the Source. This field is left empty when the current token does not have the 'source admin' permission.",
 			},
+			"disconnect_all_warehouses": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Disable to automatically connect the Source to all Warehouses of the Workspace when it is created. Changing this value forces a new Source to be created, except for imported Sources whose value is unknown. Defaults to true.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplaceIf(
+						func(_ context.Context, req planmodifier.BoolRequest, resp *boolplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = !req.StateValue.IsNull()
+						},
+						"Changing this value forces a new Source to be created, except for imported Sources.",
+						"Changing this value forces a new Source to be created, except for imported Sources.",
+					),
+				},
+			},
+			"connected_warehouse_ids": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The ids of the Warehouses connected to the Source, as of the last refresh.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"labels": schema.SetNestedAttribute{
 				Optional:    true,
 				Description: "A list of labels applied to the Source.",
@@ -241,6 +281,17 @@ func (r *sourceResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 	}
 }
 
+func (r *sourceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planMetadataID(ctx, req, resp, func(slug string, name string) (string, error) {
+		return findSourceMetadataID(r.authContext, r.client, r.catalog, slug, name)
+	})
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planFunctionMetadata(ctx, req, resp, r.authContext, r.client, "SOURCE")
+}
+
 func (r *sourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan models.SourcePlan
 	diags := req.Plan.Get(ctx, &plan)
@@ -277,14 +328,12 @@ func (r *sourceResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	disconnectAllWarehouses := true
-
 	out, body, err := r.client.SourcesAPI.CreateSource(r.authContext).CreateSourceV1Input(api.CreateSourceV1Input{
 		Slug:                    plan.Slug.ValueString(),
 		Enabled:                 plan.Enabled.ValueBool(),
 		MetadataId:              metadataID,
 		Settings:                settings,
-		DisconnectAllWarehouses: &disconnectAllWarehouses,
+		DisconnectAllWarehouses: plan.DisconnectAllWarehouses.ValueBoolPointer(),
 	}).Execute()
 	if body != nil {
 		defer body.Body.Close()
@@ -357,6 +406,18 @@ func (r *sourceResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	state.FunctionID = plan.FunctionID
+	state.DisconnectAllWarehouses = plan.DisconnectAllWarehouses
+	state.ConnectedWarehouseIDs, err = listConnectedWarehouseIDs(r.authContext, r.client, source.Id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Warehouses connected to Source (ID: %s)", source.Id),
+			err.Error(),
+		)
+
+		return
+	}
+
 	// This is to satisfy terraform requirements that the returned fields must match the input ones because new settings can be generated in the response
 	state.Settings = plan.Settings
 
@@ -415,6 +476,21 @@ func (r *sourceResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	state.FunctionID = previousState.FunctionID
+
+	// Imported Sources have no value, which is set by their next update instead of replacing them
+	state.DisconnectAllWarehouses = previousState.DisconnectAllWarehouses
+
+	state.ConnectedWarehouseIDs, err = listConnectedWarehouseIDs(r.authContext, r.client, source.Id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Warehouses connected to Source (ID: %s)", source.Id),
+			err.Error(),
+		)
+
+		return
+	}
+
 	// Merge settings: keep config-defined settings while ignoring backend-generated ones not in config
 	if !previousState.Settings.IsNull() && !previousState.Settings.IsUnknown() {
 		mergedSettings, err := mergeSettings(previousState.Settings, state.Settings, false)
@@ -538,6 +614,16 @@ func (r *sourceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	state.FunctionID = plan.FunctionID
+	state.DisconnectAllWarehouses = plan.DisconnectAllWarehouses
+
+	// The connected Warehouses are only refreshed by Read, as the plan keeps the previous ones
+	diags = plan.ConnectedWarehouseIDs.ElementsAs(ctx, &state.ConnectedWarehouseIDs, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// This is to satisfy terraform requirements that the returned fields must match the input ones because new settings can be generated in the response
 	state.Settings = plan.Settings
 
@@ -592,4 +678,30 @@ func (r *sourceResource) Configure(_ context.Context, req resource.ConfigureRequ
 
 	r.client = config.client
 	r.authContext = config.authContext
+	r.catalog = config.catalog
+}
+
+func listConnectedWarehouseIDs(authContext context.Context, client *api.APIClient, sourceID string) ([]types.String, error) {
+	warehouseIDs := []types.String{}
+	paginationInput := *api.NewPaginationInput(MaxPageSize)
+
+	for {
+		out, body, err := client.SourcesAPI.ListConnectedWarehousesFromSource(authContext, sourceID).Pagination(paginationInput).Execute()
+		if body != nil {
+			defer body.Body.Close()
+		}
+		if err != nil {
+			return nil, errors.New(getError(err, body))
+		}
+
+		for _, warehouse := range out.Data.GetWarehouses() {
+			warehouseIDs = append(warehouseIDs, types.StringValue(warehouse.Id))
+		}
+
+		next := out.Data.GetPagination().Next.Get()
+		if next == nil {
+			return warehouseIDs, nil
+		}
+		paginationInput.SetCursor(*next)
+	}
 }
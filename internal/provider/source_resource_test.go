@@ -1,11 +1,17 @@
 package provider
 
 import (
+	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/segmentio/public-api-sdk-go/api"
 )
 
 func TestAccSourceResource(t *testing.T) {
@@ -318,6 +324,35 @@ func TestAccSourceResource(t *testing.T) {
 					`
 				}
 
+			} else if req.URL.Path == "/sources/my-source-id/connected-warehouses" && req.Method == http.MethodGet {
+				payload = `
+					{
+						"data": {
+							"warehouses": [
+								{
+									"id": "my-warehouse-id",
+									"workspaceId": "my-workspace-id",
+									"enabled": true,
+									"metadata": {
+										"id": "my-warehouse-metadata-id",
+										"slug": "redshift",
+										"name": "Redshift",
+										"description": "Powered by Amazon Web Services",
+										"logos": {
+											"default": "https://example.segment.com/image.png"
+										},
+										"options": []
+									},
+									"settings": {}
+								}
+							],
+							"pagination": {
+								"current": "MA==",
+								"totalEntries": 1
+							}
+						}
+					}
+				`
 			} else if req.URL.Path == "/sources/my-source-id/settings" && req.Method == http.MethodPatch {
 				if updatedSchemaSettings == 0 {
 					payload = `
@@ -446,6 +481,9 @@ func TestAccSourceResource(t *testing.T) {
 					resource.TestCheckResourceAttr("segment_source.test", "labels.0.key", "my-label-key"),
 					resource.TestCheckResourceAttr("segment_source.test", "labels.0.value", "my-label-value"),
 					resource.TestCheckNoResourceAttr("segment_source.test", "schema_settings"),
+					resource.TestCheckResourceAttr("segment_source.test", "disconnect_all_warehouses", "true"),
+					resource.TestCheckResourceAttr("segment_source.test", "connected_warehouse_ids.#", "1"),
+					resource.TestCheckResourceAttr("segment_source.test", "connected_warehouse_ids.0", "my-warehouse-id"),
 				),
 			},
 			// ImportState testing
@@ -470,8 +508,9 @@ func TestAccSourceResource(t *testing.T) {
 						]
 					}
 				`,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"disconnect_all_warehouses"},
 			},
 			// Update and Read testing
 			{
@@ -578,3 +617,331 @@ func TestAccSourceResource(t *testing.T) {
 		},
 	})
 }
+
+func TestAccSourceResourceCatalogLookup(t *testing.T) {
+	t.Parallel()
+
+	created := 0
+	metadataID := ""
+	fakeServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("content-type", "application/json")
+
+			metadata := func(id string) string {
+				return `{
+					"id": "` + id + `",
+					"slug": "` + id + `-slug",
+					"name": "` + id + ` name",
+					"categories": [],
+					"description": "My metadata description",
+					"logos": {
+						"default": "https://example.segment.com/image.png"
+					},
+					"options": [],
+					"isCloudEventSource": false
+				}`
+			}
+			source := func() string {
+				return `{
+					"data": {
+						"source": {
+							"id": "my-source-id-` + strconv.Itoa(created) + `",
+							"slug": "my-source-slug",
+							"workspaceId": "my-workspace-id",
+							"enabled": true,
+							"writeKeys": [],
+							"metadata": ` + metadata(metadataID) + `,
+							"settings": {},
+							"labels": []
+						}
+					}
+				}`
+			}
+
+			payload := ""
+			switch {
+			case req.URL.Path == "/catalog/sources":
+				payload = `{
+					"data": {
+						"sourcesCatalog": [` + metadata("javascript") + `, ` + metadata("http-api") + `],
+						"pagination": {
+							"current": "MA==",
+							"totalEntries": 2
+						}
+					}
+				}`
+			case req.URL.Path == "/sources" && req.Method == http.MethodPost:
+				body, _ := io.ReadAll(req.Body)
+				var input api.CreateSourceV1Input
+				_ = json.Unmarshal(body, &input)
+				metadataID = input.MetadataId
+				created++
+				payload = source()
+			case strings.HasSuffix(req.URL.Path, "/connected-warehouses"):
+				payload = `{"data": {"warehouses": [], "pagination": {"current": "MA==", "totalEntries": 0}}}`
+			case req.Method == http.MethodDelete:
+				payload = `{"data": {"status": "SUCCESS"}}`
+			case req.Method == http.MethodGet || req.Method == http.MethodPatch:
+				payload = source()
+			}
+
+			_, _ = w.Write([]byte(payload))
+		}),
+	)
+	defer fakeServer.Close()
+
+	providerConfig := `
+		provider "segment" {
+			url   = "` + fakeServer.URL + `"
+			token = "abc123"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with a slug
+			{
+				Config: providerConfig + `
+					resource "segment_source" "test" {
+						slug = "my-source-slug"
+						metadata = {
+							slug = "javascript-slug"
+						}
+						enabled = true
+						settings = jsonencode({})
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_source.test", "id", "my-source-id-1"),
+					resource.TestCheckResourceAttr("segment_source.test", "metadata.id", "javascript"),
+					resource.TestCheckResourceAttr("segment_source.test", "metadata.slug", "javascript-slug"),
+					resource.TestCheckResourceAttr("segment_source.test", "metadata.name", "javascript name"),
+				),
+			},
+			// Switching to the id of the same metadata does not replace the Source
+			{
+				Config: providerConfig + `
+					resource "segment_source" "test" {
+						slug = "my-source-slug"
+						metadata = {
+							id = "javascript"
+						}
+						enabled = true
+						settings = jsonencode({})
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_source.test", "id", "my-source-id-1"),
+					resource.TestCheckResourceAttr("segment_source.test", "metadata.id", "javascript"),
+				),
+			},
+			// Looking up other metadata by name replaces the Source
+			{
+				Config: providerConfig + `
+					resource "segment_source" "test" {
+						slug = "my-source-slug"
+						metadata = {
+							name = "http-api name"
+						}
+						enabled = true
+						settings = jsonencode({})
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_source.test", "id", "my-source-id-2"),
+					resource.TestCheckResourceAttr("segment_source.test", "metadata.id", "http-api"),
+					resource.TestCheckResourceAttr("segment_source.test", "metadata.slug", "http-api-slug"),
+				),
+			},
+			// Unknown slugs are reported at plan time
+			{
+				Config: providerConfig + `
+					resource "segment_source" "test" {
+						slug = "my-source-slug"
+						metadata = {
+							slug = "my-unknown-slug"
+						}
+						enabled = true
+						settings = jsonencode({})
+					}
+				`,
+				ExpectError: regexp.MustCompile(`no Source with slug "my-unknown-slug"`),
+			},
+		},
+	})
+}
+
+func TestAccSourceResourceFunction(t *testing.T) {
+	t.Parallel()
+
+	created := 0
+	metadataID := ""
+	settings := "{}"
+	fakeServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("content-type", "application/json")
+
+			source := func() string {
+				return `{
+					"data": {
+						"source": {
+							"id": "my-source-id-` + strconv.Itoa(created) + `",
+							"slug": "my-source-slug",
+							"workspaceId": "my-workspace-id",
+							"enabled": true,
+							"writeKeys": [],
+							"metadata": {
+								"id": "` + metadataID + `",
+								"slug": "` + metadataID + `-slug",
+								"name": "My Function",
+								"categories": [],
+								"description": "My metadata description",
+								"logos": {
+									"default": "https://example.segment.com/image.png"
+								},
+								"options": [],
+								"isCloudEventSource": false
+							},
+							"settings": ` + settings + `,
+							"labels": []
+						}
+					}
+				}`
+			}
+
+			payload := ""
+			switch {
+			case strings.HasPrefix(req.URL.Path, "/functions/"):
+				// my-function-id-1 and my-function-id-2 are versions of the same catalog item
+				functionID := strings.TrimPrefix(req.URL.Path, "/functions/")
+				catalogID := "my-catalog-id-1"
+				if functionID == "my-function-id-3" {
+					catalogID = "my-catalog-id-3"
+				}
+				payload = `{
+					"data": {
+						"function": {
+							"id": "` + functionID + `",
+							"workspaceId": "my-workspace-id",
+							"displayName": "My Function",
+							"settings": [
+								{
+									"name": "apiKey",
+									"label": "API key",
+									"description": "The API key",
+									"type": "STRING",
+									"required": true,
+									"sensitive": false
+								}
+							],
+							"catalogId": "` + catalogID + `",
+							"resourceType": "SOURCE"
+						}
+					}
+				}`
+			case req.URL.Path == "/sources" && req.Method == http.MethodPost:
+				body, _ := io.ReadAll(req.Body)
+				var input api.CreateSourceV1Input
+				_ = json.Unmarshal(body, &input)
+				metadataID = input.MetadataId
+				inputSettings, _ := json.Marshal(input.Settings)
+				settings = string(inputSettings)
+				created++
+				payload = source()
+			case strings.HasSuffix(req.URL.Path, "/connected-warehouses"):
+				payload = `{"data": {"warehouses": [], "pagination": {"current": "MA==", "totalEntries": 0}}}`
+			case req.Method == http.MethodDelete:
+				payload = `{"data": {"status": "SUCCESS"}}`
+			case req.Method == http.MethodGet || req.Method == http.MethodPatch:
+				payload = source()
+			}
+
+			_, _ = w.Write([]byte(payload))
+		}),
+	)
+	defer fakeServer.Close()
+
+	providerConfig := `
+		provider "segment" {
+			url   = "` + fakeServer.URL + `"
+			token = "abc123"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create from a Function
+			{
+				Config: providerConfig + `
+					resource "segment_source" "test" {
+						slug        = "my-source-slug"
+						function_id = "my-function-id-1"
+						enabled     = true
+						settings = jsonencode({
+							"apiKey" : "my-api-key"
+						})
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_source.test", "id", "my-source-id-1"),
+					resource.TestCheckResourceAttr("segment_source.test", "function_id", "my-function-id-1"),
+					resource.TestCheckResourceAttr("segment_source.test", "metadata.id", "my-catalog-id-1"),
+					resource.TestCheckResourceAttr("segment_source.test", "metadata.name", "My Function"),
+					resource.TestCheckResourceAttr("segment_source.test", "settings", "{\"apiKey\":\"my-api-key\"}"),
+				),
+			},
+			// Switching to a Function with the same catalog id does not replace the Source
+			{
+				Config: providerConfig + `
+					resource "segment_source" "test" {
+						slug        = "my-source-slug"
+						function_id = "my-function-id-2"
+						enabled     = true
+						settings = jsonencode({
+							"apiKey" : "my-api-key"
+						})
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_source.test", "id", "my-source-id-1"),
+					resource.TestCheckResourceAttr("segment_source.test", "function_id", "my-function-id-2"),
+					resource.TestCheckResourceAttr("segment_source.test", "metadata.id", "my-catalog-id-1"),
+				),
+			},
+			// Switching to a Function with another catalog id replaces the Source
+			{
+				Config: providerConfig + `
+					resource "segment_source" "test" {
+						slug        = "my-source-slug"
+						function_id = "my-function-id-3"
+						enabled     = true
+						settings = jsonencode({
+							"apiKey" : "my-api-key"
+						})
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_source.test", "id", "my-source-id-2"),
+					resource.TestCheckResourceAttr("segment_source.test", "function_id", "my-function-id-3"),
+					resource.TestCheckResourceAttr("segment_source.test", "metadata.id", "my-catalog-id-3"),
+				),
+			},
+			// Settings are validated against the settings declared by the Function at plan time
+			{
+				Config: providerConfig + `
+					resource "segment_source" "test" {
+						slug        = "my-source-slug"
+						function_id = "my-function-id-3"
+						enabled     = true
+						settings = jsonencode({
+							"apiKey" : true
+						})
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Setting "apiKey" must be of type STRING`),
+			},
+		},
+	})
+}
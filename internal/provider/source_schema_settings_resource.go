@@ -0,0 +1,278 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+
+	"github.com/segmentio/public-api-sdk-go/api"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/docs"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
+)
+
+var (
+	_ resource.Resource                = &sourceSchemaSettingsResource{}
+	_ resource.ResourceWithConfigure   = &sourceSchemaSettingsResource{}
+	_ resource.ResourceWithImportState = &sourceSchemaSettingsResource{}
+)
+
+func NewSourceSchemaSettingsResource() resource.Resource {
+	return &sourceSchemaSettingsResource{}
+}
+
+type sourceSchemaSettingsResource struct {
+	client      *api.APIClient
+	authContext context.Context
+}
+
+func (r *sourceSchemaSettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source_schema_settings"
+}
+
+func (r *sourceSchemaSettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Configures the schema settings of a Source that is not connected to a Tracking Plan. The schema settings of Sources connected to a Tracking Plan are managed with the `schema_settings` attribute of `segment_source_tracking_plan_connection` instead. Destroying this resource removes it from the Terraform state but leaves the schema settings of the Source unchanged. For more information, visit the [Segment docs](https://segment.com/docs/protocols/enforce/schema-configuration/).\n\n" +
+			docs.GenerateImportDocs("<source_id>", "segment_source_schema_settings"),
+		Attributes: map[string]schema.Attribute{
+			"source_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The id of the Source.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema_settings": schema.SingleNestedAttribute{
+				Required:    true,
+				Description: "The schema settings associated with the Source. Fields not present in the config will not be managed by Terraform.",
+				Attributes:  schemaSettingsAttributes(),
+			},
+		},
+	}
+}
+
+func (r *sourceSchemaSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan models.SourceSchemaSettingsPlan
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, diags := r.updateSchemaSettings(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *sourceSchemaSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var previousState models.SourceSchemaSettingsState
+	diags := req.State.Get(ctx, &previousState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sourceOut, body, err := r.client.SourcesAPI.GetSource(r.authContext, previousState.SourceID.ValueString()).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		if body.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Source (ID: %s)", previousState.SourceID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+
+	if trackingPlanID := sourceTrackingPlanID(sourceOut.Data); trackingPlanID != "" {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("source_id"),
+			"Source is connected to a Tracking Plan",
+			fmt.Sprintf("Source (ID: %s) has been connected to Tracking Plan (ID: %s) since its schema settings were configured. Its schema settings should now be managed with the schema_settings attribute of segment_source_tracking_plan_connection, and this resource removed.", previousState.SourceID.ValueString(), trackingPlanID),
+		)
+	}
+
+	out, body, err := r.client.SourcesAPI.ListSchemaSettingsInSource(r.authContext, previousState.SourceID.ValueString()).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		if body.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Source schema settings (ID: %s)", previousState.SourceID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+
+	var state models.SourceSchemaSettingsState
+	state.SourceID = previousState.SourceID
+	state.SchemaSettings = &models.SchemaSettingsState{}
+	state.SchemaSettings.Fill(out.Data.Settings)
+
+	// Imported resources manage every setting, otherwise only the configured ones are kept
+	if previousState.SchemaSettings != nil {
+		state.SchemaSettings = filterOmittedSchemaSettings(previousState.SchemaSettings, state.SchemaSettings)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *sourceSchemaSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan models.SourceSchemaSettingsPlan
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, diags := r.updateSchemaSettings(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *sourceSchemaSettingsResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	// Every Source has schema settings, so they are left as they are and only removed from the state
+}
+
+func (r *sourceSchemaSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("source_id"), req, resp)
+}
+
+func (r *sourceSchemaSettingsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected ClientInfo, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = config.client
+	r.authContext = config.authContext
+}
+
+// updateSchemaSettings applies the planned schema settings to a Source that is not connected to a Tracking Plan.
+func (r *sourceSchemaSettingsResource) updateSchemaSettings(ctx context.Context, plan models.SourceSchemaSettingsPlan) (*models.SourceSchemaSettingsState, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// Sources connected to a Tracking Plan have their schema settings managed by the connection resource
+	sourceOut, body, err := r.client.SourcesAPI.GetSource(r.authContext, plan.SourceID.ValueString()).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("Unable to read Source (ID: %s)", plan.SourceID.ValueString()),
+			getError(err, body),
+		)
+
+		return nil, diags
+	}
+
+	if trackingPlanID := sourceTrackingPlanID(sourceOut.Data); trackingPlanID != "" {
+		diags.AddAttributeError(
+			path.Root("source_id"),
+			"Source is connected to a Tracking Plan",
+			fmt.Sprintf("Source (ID: %s) is connected to Tracking Plan (ID: %s). Its schema settings must be managed with the schema_settings attribute of segment_source_tracking_plan_connection instead.", plan.SourceID.ValueString(), trackingPlanID),
+		)
+
+		return nil, diags
+	}
+
+	apiSchemaSettings, d := models.GetSchemaSettingsFromPlan(ctx, plan.SchemaSettings)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	settingsOut, body, err := r.client.SourcesAPI.UpdateSchemaSettingsInSource(r.authContext, plan.SourceID.ValueString()).UpdateSchemaSettingsInSourceV1Input(api.UpdateSchemaSettingsInSourceV1Input{
+		Track:                     apiSchemaSettings.Track,
+		Identify:                  apiSchemaSettings.Identify,
+		Group:                     apiSchemaSettings.Group,
+		ForwardingViolationsTo:    apiSchemaSettings.ForwardingViolationsTo,
+		ForwardingBlockedEventsTo: apiSchemaSettings.ForwardingBlockedEventsTo,
+	}).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("Unable to update Source schema settings (ID: %s)", plan.SourceID.ValueString()),
+			getError(err, body),
+		)
+
+		return nil, diags
+	}
+
+	var state models.SourceSchemaSettingsState
+	state.SourceID = plan.SourceID
+	state.SchemaSettings = &models.SchemaSettingsState{}
+	state.SchemaSettings.Fill(settingsOut.Data.Settings)
+
+	// This is to satisfy terraform requirements that the returned fields must match the input ones because new settings can be generated in the response
+	plannedSchemaSettings, d := models.SchemaSettingsPlanToState(ctx, plan.SchemaSettings)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	state.SchemaSettings = filterOmittedSchemaSettings(plannedSchemaSettings, state.SchemaSettings)
+
+	return &state, diags
+}
+
+// sourceTrackingPlanID returns the id of the Tracking Plan the Source is connected to, or an empty string.
+func sourceTrackingPlanID(source *api.GetSourceV1Output) string {
+	if source == nil || !source.TrackingPlanId.IsSet() || source.TrackingPlanId.Get() == nil {
+		return ""
+	}
+
+	return *source.TrackingPlanId.Get()
+}
@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSourceSchemaSettingsResource(t *testing.T) {
+	t.Parallel()
+	updatedSchemaSettings := 0
+
+	fakeServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("content-type", "application/json")
+
+			payload := ""
+			if req.URL.Path == "/sources/my-source-id" && req.Method == http.MethodGet {
+				payload = `
+					{
+						"data": {
+							"source": {
+								"id": "my-source-id",
+								"slug": "my-source-slug",
+								"name": "My source name",
+								"workspaceId": "my-workspace-id",
+								"enabled": true,
+								"writeKeys": ["my-write-key"],
+								"metadata": {
+									"id": "my-metadata-id",
+									"slug": "my-metadata-slug",
+									"name": "My metadata name",
+									"categories": ["my-category"],
+									"description": "My metadata description",
+									"logos": {
+										"default": "https://example.segment.com/image.png"
+									},
+									"options": [],
+									"isCloudEventSource": false
+								},
+								"settings": {},
+								"labels": []
+							}
+						}
+					}
+				`
+			} else if req.URL.Path == "/sources/my-connected-source-id" && req.Method == http.MethodGet {
+				payload = `
+					{
+						"data": {
+							"source": {
+								"id": "my-connected-source-id",
+								"slug": "my-connected-source-slug",
+								"name": "My connected source name",
+								"workspaceId": "my-workspace-id",
+								"enabled": true,
+								"writeKeys": ["my-write-key"],
+								"metadata": {
+									"id": "my-metadata-id",
+									"slug": "my-metadata-slug",
+									"name": "My metadata name",
+									"categories": ["my-category"],
+									"description": "My metadata description",
+									"logos": {
+										"default": "https://example.segment.com/image.png"
+									},
+									"options": [],
+									"isCloudEventSource": false
+								},
+								"settings": {},
+								"labels": []
+							},
+							"trackingPlanId": "my-tracking-plan-id"
+						}
+					}
+				`
+			} else if req.URL.Path == "/sources/my-source-id/settings" {
+				if req.Method == http.MethodPatch {
+					updatedSchemaSettings++
+				}
+
+				if updatedSchemaSettings <= 1 {
+					payload = `
+						{
+							"data": {
+								"sourceId": "my-source-id",
+								"settings": {
+									"track": {
+										"allowUnplannedEvents": true,
+										"allowUnplannedEventProperties": true,
+										"allowEventOnViolations": true,
+										"allowPropertiesOnViolations": true,
+										"commonEventOnViolations": "ALLOW"
+									},
+									"group": {
+										"allowTraitsOnViolations": true,
+										"allowUnplannedTraits": true,
+										"commonEventOnViolations": "ALLOW"
+									},
+									"identify": {
+										"allowTraitsOnViolations": true,
+										"allowUnplannedTraits": true,
+										"commonEventOnViolations": "ALLOW"
+									},
+									"forwardingBlockedEventsTo": "my-other-source-id"
+								}
+							}
+						}
+					`
+				} else {
+					payload = `
+						{
+							"data": {
+								"sourceId": "my-source-id",
+								"settings": {
+									"track": {
+										"allowUnplannedEvents": false,
+										"allowUnplannedEventProperties": true,
+										"allowEventOnViolations": true,
+										"allowPropertiesOnViolations": true,
+										"commonEventOnViolations": "BLOCK"
+									},
+									"group": {
+										"allowTraitsOnViolations": true,
+										"allowUnplannedTraits": true,
+										"commonEventOnViolations": "ALLOW"
+									},
+									"identify": {
+										"allowTraitsOnViolations": true,
+										"allowUnplannedTraits": true,
+										"commonEventOnViolations": "ALLOW"
+									},
+									"forwardingBlockedEventsTo": "my-other-source-id"
+								}
+							}
+						}
+					`
+				}
+			}
+
+			_, _ = w.Write([]byte(payload))
+		}),
+	)
+	defer fakeServer.Close()
+
+	providerConfig := `
+			provider "segment" {
+				url   = "` + fakeServer.URL + `"
+				token = "abc123"
+			}
+		`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Sources connected to a Tracking Plan are rejected
+			{
+				Config: providerConfig + `
+					resource "segment_source_schema_settings" "test" {
+						source_id = "my-connected-source-id"
+						schema_settings = {
+							forwarding_blocked_events_to = "my-other-source-id"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile("Source is connected to a Tracking Plan"),
+			},
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+					resource "segment_source_schema_settings" "test" {
+						source_id = "my-source-id"
+						schema_settings = {
+							forwarding_blocked_events_to = "my-other-source-id"
+							track = {
+								allow_unplanned_events = true
+							}
+						}
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_source_schema_settings.test", "source_id", "my-source-id"),
+					resource.TestCheckResourceAttr("segment_source_schema_settings.test", "schema_settings.forwarding_blocked_events_to", "my-other-source-id"),
+					resource.TestCheckResourceAttr("segment_source_schema_settings.test", "schema_settings.track.allow_unplanned_events", "true"),
+					resource.TestCheckNoResourceAttr("segment_source_schema_settings.test", "schema_settings.track.common_event_on_violations"),
+					resource.TestCheckNoResourceAttr("segment_source_schema_settings.test", "schema_settings.forwarding_violations_to"),
+					resource.TestCheckNoResourceAttr("segment_source_schema_settings.test", "schema_settings.identify"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+					resource "segment_source_schema_settings" "test" {
+						source_id = "my-source-id"
+						schema_settings = {
+							forwarding_blocked_events_to = "my-other-source-id"
+							track = {
+								allow_unplanned_events = false
+								common_event_on_violations = "BLOCK"
+							}
+						}
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_source_schema_settings.test", "source_id", "my-source-id"),
+					resource.TestCheckResourceAttr("segment_source_schema_settings.test", "schema_settings.forwarding_blocked_events_to", "my-other-source-id"),
+					resource.TestCheckResourceAttr("segment_source_schema_settings.test", "schema_settings.track.allow_unplanned_events", "false"),
+					resource.TestCheckResourceAttr("segment_source_schema_settings.test", "schema_settings.track.common_event_on_violations", "BLOCK"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:  "segment_source_schema_settings.test",
+				ImportState:   true,
+				ImportStateId: "my-source-id",
+			},
+		},
+	})
+}
@@ -2,17 +2,21 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/avast/retry-go/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/segmentio/public-api-sdk-go/api"
@@ -25,6 +29,14 @@ var (
 	_ resource.ResourceWithConfigure = &sourceTrackingPlanConnectionResource{}
 )
 
+const (
+	SchemaSettingsOnDestroyRestore = "RESTORE"
+	SchemaSettingsOnDestroyReset   = "RESET"
+	SchemaSettingsOnDestroyKeep    = "KEEP"
+
+	previousSchemaSettingsKey = "previous_schema_settings"
+)
+
 func NewSourceTrackingPlanConnectionResource() resource.Resource {
 	return &sourceTrackingPlanConnectionResource{}
 }
@@ -76,77 +88,15 @@ func (r *sourceTrackingPlanConnectionResource) Schema(_ context.Context, _ resou
 			"schema_settings": schema.SingleNestedAttribute{
 				Optional:    true,
 				Description: "The schema settings associated with the Source. Upon import, this field will be empty even if the settings have already been configured due to Terraform limitations, but will be populated on the first apply. Fields not present in the config will not be managed by Terraform.",
-				Attributes: map[string]schema.Attribute{
-					"track": schema.SingleNestedAttribute{
-						Optional:    true,
-						Description: "Track settings.",
-						Attributes: map[string]schema.Attribute{
-							"allow_unplanned_events": schema.BoolAttribute{
-								Optional:    true,
-								Description: "Enable to allow unplanned track events.",
-							},
-							"allow_unplanned_event_properties": schema.BoolAttribute{
-								Optional:    true,
-								Description: "Enable to allow unplanned track event properties.",
-							},
-							"allow_event_on_violations": schema.BoolAttribute{
-								Optional:    true,
-								Description: "Allow track event on violations.",
-							},
-							"allow_properties_on_violations": schema.BoolAttribute{
-								Optional:    true,
-								Description: "Enable to allow track properties on violations.",
-							},
-							"common_event_on_violations": schema.StringAttribute{
-								Optional:    true,
-								Description: "The common track event on violations.",
-							},
-						},
-					},
-					"identify": schema.SingleNestedAttribute{
-						Optional:    true,
-						Description: "Identify settings.",
-						Attributes: map[string]schema.Attribute{
-							"allow_unplanned_traits": schema.BoolAttribute{
-								Optional:    true,
-								Description: "Enable to allow unplanned identify traits.",
-							},
-							"allow_traits_on_violations": schema.BoolAttribute{
-								Optional:    true,
-								Description: "Enable to allow identify traits on violations.",
-							},
-							"common_event_on_violations": schema.StringAttribute{
-								Optional:    true,
-								Description: "The common identify event on violations.",
-							},
-						},
-					},
-					"group": schema.SingleNestedAttribute{
-						Optional:    true,
-						Description: "Group settings.",
-						Attributes: map[string]schema.Attribute{
-							"allow_unplanned_traits": schema.BoolAttribute{
-								Optional:    true,
-								Description: "Enable to allow unplanned group traits.",
-							},
-							"allow_traits_on_violations": schema.BoolAttribute{
-								Optional:    true,
-								Description: "Enable to allow group traits on violations.",
-							},
-							"common_event_on_violations": schema.StringAttribute{
-								Optional:    true,
-								Description: "The common group event on violations.",
-							},
-						},
-					},
-					"forwarding_violations_to": schema.StringAttribute{
-						Optional:    true,
-						Description: "Source id to forward violations to.",
-					},
-					"forwarding_blocked_events_to": schema.StringAttribute{
-						Optional:    true,
-						Description: "Source id to forward blocked events to.",
-					},
+				Attributes:  schemaSettingsAttributes(),
+			},
+			"schema_settings_on_destroy": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(SchemaSettingsOnDestroyRestore),
+				Description: "What happens to the Source's schema settings when this connection is destroyed. 'RESTORE' restores the settings the Source had before the connection was created, 'RESET' resets them to the Segment defaults and 'KEEP' leaves them unchanged. Imported connections have no saved settings, so 'RESTORE' leaves them unchanged. Defaults to 'RESTORE'.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(SchemaSettingsOnDestroyRestore, SchemaSettingsOnDestroyReset, SchemaSettingsOnDestroyKeep),
 				},
 			},
 		},
@@ -167,7 +117,35 @@ func (r *sourceTrackingPlanConnectionResource) Create(ctx context.Context, req r
 		return
 	}
 
-	err := retry.Do(
+	// Save the current schema settings so they can be restored when the connection is destroyed
+	previousSettingsOut, body, err := r.client.SourcesAPI.ListSchemaSettingsInSource(r.authContext, plan.SourceID.ValueString()).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Source schema settings (ID: %s)", plan.SourceID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+
+	previousSettings, err := json.Marshal(previousSettingsOut.Data.Settings)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to save Source schema settings",
+			err.Error(),
+		)
+
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, previousSchemaSettingsKey, previousSettings)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err = retry.Do(
 		func() error {
 			_, body, err := r.client.TrackingPlansAPI.AddSourceToTrackingPlan(r.authContext, plan.TrackingPlanID.ValueString()).AddSourceToTrackingPlanV1Input(api.AddSourceToTrackingPlanV1Input{
 				SourceId: plan.SourceID.ValueString(),
@@ -224,6 +202,7 @@ func (r *sourceTrackingPlanConnectionResource) Create(ctx context.Context, req r
 
 	var state models.SourceTrackingPlanConnectionState
 	state.Fill(plan.SourceID.ValueString(), plan.TrackingPlanID.ValueString(), schemaSettings)
+	state.SchemaSettingsOnDestroy = plan.SchemaSettingsOnDestroy
 
 	// This is to satisfy terraform requirements that the returned fields must match the input ones because new settings can be generated in the response
 	plannedSchemaSettings, diags := models.SchemaSettingsPlanToState(ctx, plan.SchemaSettings)
@@ -251,6 +230,11 @@ func (r *sourceTrackingPlanConnectionResource) Read(ctx context.Context, req res
 		return
 	}
 
+	// Imported connections have no value yet
+	if previousState.SchemaSettingsOnDestroy.IsNull() {
+		previousState.SchemaSettingsOnDestroy = types.StringValue(SchemaSettingsOnDestroyRestore)
+	}
+
 	out, body, err := r.client.SourcesAPI.GetSource(r.authContext, previousState.SourceID.ValueString()).Execute()
 	if body != nil {
 		defer body.Body.Close()
@@ -272,8 +256,9 @@ func (r *sourceTrackingPlanConnectionResource) Read(ctx context.Context, req res
 
 	if !out.Data.TrackingPlanId.IsSet() || out.Data.TrackingPlanId.Get() == nil || *out.Data.TrackingPlanId.Get() != previousState.TrackingPlanID.ValueString() {
 		diags = resp.State.Set(ctx, &models.SourceTrackingPlanConnectionState{
-			SourceID:       previousState.SourceID,
-			TrackingPlanID: types.StringValue(""),
+			SourceID:                previousState.SourceID,
+			TrackingPlanID:          types.StringValue(""),
+			SchemaSettingsOnDestroy: previousState.SchemaSettingsOnDestroy,
 		})
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
@@ -376,6 +361,7 @@ func (r *sourceTrackingPlanConnectionResource) Update(ctx context.Context, req r
 
 	var state models.SourceTrackingPlanConnectionState
 	state.Fill(plan.SourceID.ValueString(), plan.TrackingPlanID.ValueString(), schemaSettings)
+	state.SchemaSettingsOnDestroy = plan.SchemaSettingsOnDestroy
 
 	// This is to satisfy terraform requirements that the returned fields must match the input ones because new settings can be generated in the response
 	plannedSchemaSettings, diags := models.SchemaSettingsPlanToState(ctx, plan.SchemaSettings)
@@ -416,6 +402,70 @@ func (r *sourceTrackingPlanConnectionResource) Delete(ctx context.Context, req r
 			return
 		}
 	}
+
+	if config.SourceID.ValueString() == "" {
+		return
+	}
+
+	var settings api.SourceSettingsOutputV1
+	switch config.SchemaSettingsOnDestroy.ValueString() {
+	case SchemaSettingsOnDestroyReset:
+		settings = models.DefaultSchemaSettings()
+	case SchemaSettingsOnDestroyRestore:
+		previousSettings, diags := req.Private.GetKey(ctx, previousSchemaSettingsKey)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if previousSettings == nil {
+			resp.Diagnostics.AddWarning(
+				"Source schema settings were not restored",
+				fmt.Sprintf("No schema settings were saved when the connection was created, so the schema settings of Source (ID: %s) were left unchanged.", config.SourceID.ValueString()),
+			)
+
+			return
+		}
+
+		err := json.Unmarshal(previousSettings, &settings)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read saved Source schema settings",
+				err.Error(),
+			)
+
+			return
+		}
+
+		// Forwarding settings are omitted when unset, so they need to be cleared explicitly
+		noForwarding := ""
+		if settings.ForwardingViolationsTo == nil {
+			settings.ForwardingViolationsTo = &noForwarding
+		}
+		if settings.ForwardingBlockedEventsTo == nil {
+			settings.ForwardingBlockedEventsTo = &noForwarding
+		}
+	default:
+		return
+	}
+
+	_, body, err := r.client.SourcesAPI.UpdateSchemaSettingsInSource(r.authContext, config.SourceID.ValueString()).UpdateSchemaSettingsInSourceV1Input(api.UpdateSchemaSettingsInSourceV1Input{
+		Track:                     settings.Track,
+		Identify:                  settings.Identify,
+		Group:                     settings.Group,
+		ForwardingViolationsTo:    settings.ForwardingViolationsTo,
+		ForwardingBlockedEventsTo: settings.ForwardingBlockedEventsTo,
+	}).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to restore Source schema settings (ID: %s)", config.SourceID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
 }
 
 func (r *sourceTrackingPlanConnectionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -437,6 +487,82 @@ func (r *sourceTrackingPlanConnectionResource) Configure(_ context.Context, req
 	r.authContext = config.authContext
 }
 
+// Attributes of the Source schema settings, shared by the resources that manage them.
+func schemaSettingsAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"track": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "Track settings.",
+			Attributes: map[string]schema.Attribute{
+				"allow_unplanned_events": schema.BoolAttribute{
+					Optional:    true,
+					Description: "Enable to allow unplanned track events.",
+				},
+				"allow_unplanned_event_properties": schema.BoolAttribute{
+					Optional:    true,
+					Description: "Enable to allow unplanned track event properties.",
+				},
+				"allow_event_on_violations": schema.BoolAttribute{
+					Optional:    true,
+					Description: "Allow track event on violations.",
+				},
+				"allow_properties_on_violations": schema.BoolAttribute{
+					Optional:    true,
+					Description: "Enable to allow track properties on violations.",
+				},
+				"common_event_on_violations": schema.StringAttribute{
+					Optional:    true,
+					Description: "The common track event on violations.",
+				},
+			},
+		},
+		"identify": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "Identify settings.",
+			Attributes: map[string]schema.Attribute{
+				"allow_unplanned_traits": schema.BoolAttribute{
+					Optional:    true,
+					Description: "Enable to allow unplanned identify traits.",
+				},
+				"allow_traits_on_violations": schema.BoolAttribute{
+					Optional:    true,
+					Description: "Enable to allow identify traits on violations.",
+				},
+				"common_event_on_violations": schema.StringAttribute{
+					Optional:    true,
+					Description: "The common identify event on violations.",
+				},
+			},
+		},
+		"group": schema.SingleNestedAttribute{
+			Optional:    true,
+			Description: "Group settings.",
+			Attributes: map[string]schema.Attribute{
+				"allow_unplanned_traits": schema.BoolAttribute{
+					Optional:    true,
+					Description: "Enable to allow unplanned group traits.",
+				},
+				"allow_traits_on_violations": schema.BoolAttribute{
+					Optional:    true,
+					Description: "Enable to allow group traits on violations.",
+				},
+				"common_event_on_violations": schema.StringAttribute{
+					Optional:    true,
+					Description: "The common group event on violations.",
+				},
+			},
+		},
+		"forwarding_violations_to": schema.StringAttribute{
+			Optional:    true,
+			Description: "Source id to forward violations to.",
+		},
+		"forwarding_blocked_events_to": schema.StringAttribute{
+			Optional:    true,
+			Description: "Source id to forward blocked events to.",
+		},
+	}
+}
+
 // Filters out fields that were omitted from the plan to ensure consistent terraform state.
 func filterOmittedSchemaSettings(plannedState *models.SchemaSettingsState, returnedState *models.SchemaSettingsState) *models.SchemaSettingsState {
 	if plannedState == nil || returnedState == nil {
@@ -1,16 +1,21 @@
 package provider
 
 import (
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccSourceTrackingPlanConnectionResource(t *testing.T) {
 	t.Parallel()
 	updatedSchemaSettings := 0
+	lastSchemaSettingsUpdate := ""
 
 	fakeServer := httptest.NewServer(
 		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
@@ -197,6 +202,8 @@ func TestAccSourceTrackingPlanConnectionResource(t *testing.T) {
 					`
 				}
 
+				body, _ := io.ReadAll(req.Body)
+				lastSchemaSettingsUpdate = string(body)
 				updatedSchemaSettings++
 			}
 
@@ -214,6 +221,14 @@ func TestAccSourceTrackingPlanConnectionResource(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// The schema settings saved when the connection was created are restored on destroy
+		CheckDestroy: func(_ *terraform.State) error {
+			if !strings.Contains(lastSchemaSettingsUpdate, `"commonEventOnViolations":"OMIT_PROPERTIES"`) {
+				return fmt.Errorf("expected previous schema settings to be restored, got: %s", lastSchemaSettingsUpdate)
+			}
+
+			return nil
+		},
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
@@ -227,6 +242,7 @@ func TestAccSourceTrackingPlanConnectionResource(t *testing.T) {
 					resource.TestCheckResourceAttr("segment_source_tracking_plan_connection.test", "source_id", "my-source-id"),
 					resource.TestCheckResourceAttr("segment_source_tracking_plan_connection.test", "tracking_plan_id", "my-tracking-plan-id"),
 					resource.TestCheckNoResourceAttr("segment_source_tracking_plan_connection.test", "schema_settings"),
+					resource.TestCheckResourceAttr("segment_source_tracking_plan_connection.test", "schema_settings_on_destroy", "RESTORE"),
 				),
 			},
 			// Update and Read testing
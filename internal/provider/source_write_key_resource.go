@@ -0,0 +1,281 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/segmentio/public-api-sdk-go/api"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/docs"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
+)
+
+// importedWriteKeyKey marks in the private state that the write key was imported, and so has no keepers yet.
+const importedWriteKeyKey = "imported"
+
+var (
+	_ resource.Resource                = &sourceWriteKeyResource{}
+	_ resource.ResourceWithConfigure   = &sourceWriteKeyResource{}
+	_ resource.ResourceWithImportState = &sourceWriteKeyResource{}
+)
+
+func NewSourceWriteKeyResource() resource.Resource {
+	return &sourceWriteKeyResource{}
+}
+
+type sourceWriteKeyResource struct {
+	client      *api.APIClient
+	authContext context.Context
+	sourceLocks *keyedMutex
+}
+
+func (r *sourceWriteKeyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source_write_key"
+}
+
+func (r *sourceWriteKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Configures an additional write key for a Source. To rotate a write key without downtime, create a new write key, update the clients that send data to the Source, and then remove the resource of the old write key. Changing `keepers` together with the `create_before_destroy` lifecycle rotates the write key in a single apply. The current token must have the 'source admin' permission.\n\n" +
+			docs.GenerateImportDocs("<source_id>:<write_key>", "segment_source_write_key"),
+		Attributes: map[string]schema.Attribute{
+			"source_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The id of the Source.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"write_key": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The write key created for the Source.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"keepers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Arbitrary values that, when changed, create a new write key and remove the current one. Setting them on an imported write key, which has no keepers, does not create a new write key.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.MapRequest, resp *mapplanmodifier.RequiresReplaceIfFuncResponse) {
+							imported, diags := req.Private.GetKey(ctx, importedWriteKeyKey)
+							resp.Diagnostics.Append(diags...)
+							resp.RequiresReplace = !req.StateValue.IsNull() || imported == nil
+						},
+						"Changing this value forces a new write key to be created, except when it is first set on an imported write key.",
+						"Changing this value forces a new write key to be created, except when it is first set on an imported write key.",
+					),
+				},
+			},
+		},
+	}
+}
+
+func (r *sourceWriteKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan models.SourceWriteKeyState
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The existing write keys are needed to tell which one is created, so another write key of the Source must not be created meanwhile
+	unlock := r.sourceLocks.Lock(plan.SourceID.ValueString())
+	defer unlock()
+
+	sourceOut, body, err := r.client.SourcesAPI.GetSource(r.authContext, plan.SourceID.ValueString()).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Source (ID: %s)", plan.SourceID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+	previousWriteKeys := sourceOut.Data.Source.WriteKeys
+
+	out, body, err := r.client.SourcesAPI.CreateWriteKeyForSource(r.authContext, plan.SourceID.ValueString()).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to create write key for Source (ID: %s)", plan.SourceID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+
+	writeKey := ""
+	for _, key := range out.Data.Source.WriteKeys {
+		if !slices.Contains(previousWriteKeys, key) {
+			writeKey = key
+
+			break
+		}
+	}
+	if writeKey == "" {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to create write key for Source (ID: %s)", plan.SourceID.ValueString()),
+			"The created write key was not returned. Make sure the current token has the 'source admin' permission.",
+		)
+
+		return
+	}
+
+	plan.WriteKey = types.StringValue(writeKey)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *sourceWriteKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state models.SourceWriteKeyState
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	out, body, err := r.client.SourcesAPI.GetSource(r.authContext, state.SourceID.ValueString()).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		if body.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Source (ID: %s)", state.SourceID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+
+	// A token without the 'source admin' permission cannot see any write key, which must not be mistaken for a removed one
+	if len(out.Data.Source.WriteKeys) == 0 {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read write keys of Source (ID: %s)", state.SourceID.ValueString()),
+			"No write key was returned. Make sure the current token has the 'source admin' permission.",
+		)
+
+		return
+	}
+
+	if !slices.Contains(out.Data.Source.WriteKeys, state.WriteKey.ValueString()) {
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *sourceWriteKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every attribute requires replacement, except the keepers first set on an imported write key
+	var plan models.SourceWriteKeyState
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Later changes of the keepers create a new write key
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedWriteKeyKey, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *sourceWriteKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var config models.SourceWriteKeyState
+	diags := req.State.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, body, err := r.client.SourcesAPI.RemoveWriteKeyFromSource(r.authContext, config.SourceID.ValueString(), config.WriteKey.ValueString()).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to remove write key from Source (ID: %s)", config.SourceID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+}
+
+func (r *sourceWriteKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, ":")
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <source_id>:<write_key>. Got: %q", req.ID),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("write_key"), idParts[1])...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedWriteKeyKey, []byte("true"))...)
+}
+
+func (r *sourceWriteKeyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected ClientInfo, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = config.client
+	r.authContext = config.authContext
+	r.sourceLocks = config.sourceLocks
+}
@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSourceWriteKeyResource(t *testing.T) {
+	t.Parallel()
+	writeKeys := `["my-write-key"]`
+
+	fakeServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("content-type", "application/json")
+
+			if req.URL.Path == "/sources/my-source-id/writekey" && req.Method == http.MethodPost {
+				if writeKeys == `["my-write-key"]` {
+					writeKeys = `["my-write-key", "my-new-write-key"]`
+				} else {
+					writeKeys = `["my-write-key", "my-new-write-key", "my-other-new-write-key"]`
+				}
+			} else if req.URL.Path == "/sources/my-source-id/writekey/my-new-write-key" && req.Method == http.MethodDelete {
+				writeKeys = `["my-write-key", "my-other-new-write-key"]`
+				_, _ = w.Write([]byte(`{"data": {"status": "SUCCESS"}}`))
+
+				return
+			} else if req.URL.Path == "/sources/my-source-id/writekey/my-other-new-write-key" && req.Method == http.MethodDelete {
+				writeKeys = `["my-write-key"]`
+				_, _ = w.Write([]byte(`{"data": {"status": "SUCCESS"}}`))
+
+				return
+			}
+
+			payload := `
+				{
+					"data": {
+						"source": {
+							"id": "my-source-id",
+							"slug": "my-source-slug",
+							"name": "My source name",
+							"workspaceId": "my-workspace-id",
+							"enabled": true,
+							"writeKeys": ` + writeKeys + `,
+							"metadata": {
+								"id": "my-metadata-id",
+								"slug": "my-metadata-slug",
+								"name": "My metadata name",
+								"categories": ["my-category"],
+								"description": "My metadata description",
+								"logos": {
+									"default": "https://example.segment.com/image.png"
+								},
+								"options": [],
+								"isCloudEventSource": false
+							},
+							"settings": {},
+							"labels": []
+						}
+					}
+				}
+			`
+
+			_, _ = w.Write([]byte(payload))
+		}),
+	)
+	defer fakeServer.Close()
+
+	providerConfig := `
+		provider "segment" {
+			url   = "` + fakeServer.URL + `"
+			token = "abc123"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+					resource "segment_source_write_key" "test" {
+						source_id = "my-source-id"
+						keepers = {
+							rotation = "1"
+						}
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_source_write_key.test", "source_id", "my-source-id"),
+					resource.TestCheckResourceAttr("segment_source_write_key.test", "write_key", "my-new-write-key"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:  "segment_source_write_key.test",
+				ImportState:   true,
+				ImportStateId: "my-source-id:my-new-write-key",
+			},
+			// Rotation testing
+			{
+				Config: providerConfig + `
+					resource "segment_source_write_key" "test" {
+						source_id = "my-source-id"
+						keepers = {
+							rotation = "2"
+						}
+
+						lifecycle {
+							create_before_destroy = true
+						}
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_source_write_key.test", "source_id", "my-source-id"),
+					resource.TestCheckResourceAttr("segment_source_write_key.test", "write_key", "my-other-new-write-key"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSourceWriteKeyResourceImport(t *testing.T) {
+	t.Parallel()
+	writeKeys := `["my-write-key"]`
+
+	fakeServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("content-type", "application/json")
+
+			if req.URL.Path == "/sources/my-source-id/writekey" && req.Method == http.MethodPost {
+				writeKeys = `["my-write-key", "my-new-write-key"]`
+			} else if strings.HasPrefix(req.URL.Path, "/sources/my-source-id/writekey/") && req.Method == http.MethodDelete {
+				_, _ = w.Write([]byte(`{"data": {"status": "SUCCESS"}}`))
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`
+				{
+					"data": {
+						"source": {
+							"id": "my-source-id",
+							"slug": "my-source-slug",
+							"name": "My source name",
+							"workspaceId": "my-workspace-id",
+							"enabled": true,
+							"writeKeys": ` + writeKeys + `,
+							"metadata": {
+								"id": "my-metadata-id",
+								"slug": "my-metadata-slug",
+								"name": "My metadata name",
+								"categories": ["my-category"],
+								"description": "My metadata description",
+								"logos": {
+									"default": "https://example.segment.com/image.png"
+								},
+								"options": [],
+								"isCloudEventSource": false
+							},
+							"settings": {},
+							"labels": []
+						}
+					}
+				}
+			`))
+		}),
+	)
+	defer fakeServer.Close()
+
+	providerConfig := `
+		provider "segment" {
+			url   = "` + fakeServer.URL + `"
+			token = "abc123"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// ImportState testing
+			{
+				Config: providerConfig + `
+					resource "segment_source_write_key" "test" {
+						source_id = "my-source-id"
+					}
+				`,
+				ResourceName:       "segment_source_write_key.test",
+				ImportState:        true,
+				ImportStateId:      "my-source-id:my-write-key",
+				ImportStatePersist: true,
+			},
+			// Keepers set on the imported write key do not create a new write key
+			{
+				Config: providerConfig + `
+					resource "segment_source_write_key" "test" {
+						source_id = "my-source-id"
+						keepers = {
+							rotation = "1"
+						}
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_source_write_key.test", "write_key", "my-write-key"),
+					resource.TestCheckResourceAttr("segment_source_write_key.test", "keepers.rotation", "1"),
+				),
+			},
+			// Later changes of the keepers create a new write key
+			{
+				Config: providerConfig + `
+					resource "segment_source_write_key" "test" {
+						source_id = "my-source-id"
+						keepers = {
+							rotation = "2"
+						}
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_source_write_key.test", "write_key", "my-new-write-key"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/segmentio/public-api-sdk-go/api"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
+)
+
+var (
+	_ datasource.DataSource              = &spaceDataSource{}
+	_ datasource.DataSourceWithConfigure = &spaceDataSource{}
+)
+
+func NewSpaceDataSource() datasource.DataSource {
+	return &spaceDataSource{}
+}
+
+type spaceDataSource struct {
+	client      *api.APIClient
+	authContext context.Context
+}
+
+func (d *spaceDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space"
+}
+
+func (d *spaceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads a Unify Space, such as to reference it by name where a `space_id` is required. The Sources connected to the Space are not returned, since the Public API does not expose them. For more information, visit the [Segment docs](https://segment.com/docs/unify/quickstart/).",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The id of the Space. Exactly one of `id` or `name` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("name")),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the Space. Can be set instead of `id` to look up the Space, as long as no other Space has the same name.",
+			},
+			"slug": schema.StringAttribute{
+				Computed:    true,
+				Description: "The URL-friendly slug of the Space.",
+			},
+		},
+	}
+}
+
+func (d *spaceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config models.SpaceState
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var space api.Space
+	if config.ID.IsNull() {
+		spaces, err := listSpaces(d.authContext, d.client)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read Spaces",
+				err.Error(),
+			)
+
+			return
+		}
+
+		space, err = findSpaceByName(spaces, config.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Unable to find Space",
+				err.Error(),
+			)
+
+			return
+		}
+	} else {
+		out, body, err := d.client.SpacesAPI.GetSpace(d.authContext, config.ID.ValueString()).Execute()
+		if body != nil {
+			defer body.Body.Close()
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Unable to read Space (ID: %s)", config.ID.ValueString()),
+				getError(err, body),
+			)
+
+			return
+		}
+
+		space = out.Data.GetSpace()
+	}
+
+	var state models.SpaceState
+	state.Fill(space)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (d *spaceDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected ClientInfo, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = config.client
+	d.authContext = config.authContext
+}
+
+// findSpaceByName returns the only Space with the given name, since names are not unique.
+func findSpaceByName(spaces []api.Space, name string) (api.Space, error) {
+	matches := []api.Space{}
+	for _, space := range spaces {
+		if space.Name == name {
+			matches = append(matches, space)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return api.Space{}, fmt.Errorf("no Space with name %q was found", name)
+	case 1:
+		return matches[0], nil
+	default:
+		ids := make([]string, 0, len(matches))
+		for _, match := range matches {
+			ids = append(ids, match.Id)
+		}
+
+		return api.Space{}, fmt.Errorf("more than one Space with name %q was found, use one of the following ids instead: %s", name, strings.Join(ids, ", "))
+	}
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSpaceDataSource(t *testing.T) {
+	t.Parallel()
+
+	fakeServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("content-type", "application/json")
+
+			if req.URL.Path == "/spaces/my-space-id" {
+				_, _ = w.Write([]byte(`
+					{
+						"data": {
+							"space": {
+								"id": "my-space-id",
+								"name": "Production",
+								"slug": "production"
+							}
+						}
+					}
+				`))
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`
+				{
+					"data": {
+						"spaces": [
+							{
+								"id": "my-space-id",
+								"name": "Production",
+								"slug": "production"
+							},
+							{
+								"id": "my-other-space-id",
+								"name": "Staging",
+								"slug": "staging"
+							},
+							{
+								"id": "my-third-space-id",
+								"name": "Staging",
+								"slug": "staging-2"
+							}
+						],
+						"pagination": {
+							"current": "MA=="
+						}
+					}
+				}
+			`))
+		}),
+	)
+	defer fakeServer.Close()
+
+	providerConfig := `
+		provider "segment" {
+			url   = "` + fakeServer.URL + `"
+			token = "abc123"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read by id testing
+			{
+				Config: providerConfig + `data "segment_space" "test" { id = "my-space-id" }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.segment_space.test", "id", "my-space-id"),
+					resource.TestCheckResourceAttr("data.segment_space.test", "name", "Production"),
+					resource.TestCheckResourceAttr("data.segment_space.test", "slug", "production"),
+				),
+			},
+			// Read by name testing
+			{
+				Config: providerConfig + `data "segment_space" "test" { name = "Production" }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.segment_space.test", "id", "my-space-id"),
+					resource.TestCheckResourceAttr("data.segment_space.test", "slug", "production"),
+				),
+			},
+			// Ambiguous names are reported
+			{
+				Config:      providerConfig + `data "segment_space" "test" { name = "Staging" }`,
+				ExpectError: regexp.MustCompile(`more than one Space with name "Staging" was found`),
+			},
+			// List testing
+			{
+				Config: providerConfig + `data "segment_spaces" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.segment_spaces.test", "spaces.#", "3"),
+					resource.TestCheckResourceAttr("data.segment_spaces.test", "spaces.1.id", "my-other-space-id"),
+					resource.TestCheckResourceAttr("data.segment_spaces.test", "spaces.1.name", "Staging"),
+					resource.TestCheckResourceAttr("data.segment_spaces.test", "spaces.1.slug", "staging"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+
+	"github.com/segmentio/public-api-sdk-go/api"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
+)
+
+var (
+	_ datasource.DataSource              = &spacesDataSource{}
+	_ datasource.DataSourceWithConfigure = &spacesDataSource{}
+)
+
+func NewSpacesDataSource() datasource.DataSource {
+	return &spacesDataSource{}
+}
+
+type spacesDataSource struct {
+	client      *api.APIClient
+	authContext context.Context
+}
+
+func (d *spacesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_spaces"
+}
+
+func (d *spacesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the Unify Spaces of the Workspace. The Sources connected to each Space are not returned, since the Public API does not expose them. For more information, visit the [Segment docs](https://segment.com/docs/unify/quickstart/).",
+		Attributes: map[string]schema.Attribute{
+			"spaces": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The Spaces of the Workspace.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The id of the Space.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the Space.",
+						},
+						"slug": schema.StringAttribute{
+							Computed:    true,
+							Description: "The URL-friendly slug of the Space.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *spacesDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	spaces, err := listSpaces(d.authContext, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read Spaces",
+			err.Error(),
+		)
+
+		return
+	}
+
+	var state models.SpacesState
+	state.Fill(spaces)
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (d *spacesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected ClientInfo, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = config.client
+	d.authContext = config.authContext
+}
+
+func listSpaces(authContext context.Context, client *api.APIClient) ([]api.Space, error) {
+	spaces := []api.Space{}
+	paginationInput := *api.NewPaginationInput(MaxPageSize)
+
+	for {
+		out, body, err := client.SpacesAPI.ListSpaces(authContext).Pagination(paginationInput).Execute()
+		if body != nil {
+			defer body.Body.Close()
+		}
+		if err != nil {
+			return nil, errors.New(getError(err, body))
+		}
+
+		spaces = append(spaces, out.Data.GetSpaces()...)
+
+		next := out.Data.GetPagination().Next.Get()
+		if next == nil {
+			return spaces, nil
+		}
+		paginationInput.SetCursor(*next)
+	}
+}
@@ -2,12 +2,20 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/segmentio/public-api-sdk-go/api"
 )
 
@@ -82,7 +90,7 @@ func (d *trackingPlanDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 			},
 			"rules": schema.SetNestedAttribute{
 				Computed:    true,
-				Description: `The list of Tracking Plan rules. Currently limited to 200 rules.`,
+				Description: "The list of Tracking Plan rules matching 'rule_filter'.",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"type": schema.StringAttribute{
@@ -118,6 +126,61 @@ func (d *trackingPlanDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 					},
 				},
 			},
+			"rule_filter": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Filters the rules returned in 'rules', 'typewriter_plan' and 'type_definitions'. A rule must match all of the set filters.",
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Optional: true,
+						Description: `Only return rules of this type.
+
+						Enum: "COMMON" "GROUP" "IDENTIFY" "PAGE" "SCREEN" "TRACK"`,
+						Validators: []validator.String{
+							stringvalidator.OneOf("COMMON", "GROUP", "IDENTIFY", "PAGE", "SCREEN", "TRACK"),
+						},
+					},
+					"key_prefix": schema.StringAttribute{
+						Optional:    true,
+						Description: "Only return rules whose key starts with this prefix.",
+					},
+					"key_regex": schema.StringAttribute{
+						Optional:    true,
+						Description: "Only return rules whose key matches this regular expression.",
+					},
+					"version": schema.Float64Attribute{
+						Optional:    true,
+						Description: "Only return rules with this version.",
+					},
+				},
+			},
+			"source_ids": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The ids of the Sources connected to this Tracking Plan.",
+			},
+			"export_typewriter_plan": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to export the Tracking Plan in 'typewriter_plan'. The export requires permission to read the Workspace.",
+			},
+			"typewriter_plan": schema.StringAttribute{
+				Computed:    true,
+				Description: "The Tracking Plan exported in the Typewriter plan.json format when 'export_typewriter_plan' is set. Can be written to disk with the `local_file` resource to generate analytics clients with Typewriter.",
+			},
+			"generate_type_definitions": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: `The languages to generate type definitions for in 'type_definitions'.
+
+				Enum: "typescript" "go"`,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(TypeDefinitionsTypeScript, TypeDefinitionsGo)),
+				},
+			},
+			"type_definitions": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Type definitions for the payload of every TRACK, IDENTIFY and GROUP rule, keyed by the languages requested in 'generate_type_definitions'. Go definitions are generated in the 'trackingplan' package.",
+			},
 		},
 	}
 }
@@ -152,21 +215,39 @@ func (d *trackingPlanDataSource) Read(ctx context.Context, req datasource.ReadRe
 
 	trackingPlan := out.Data.GetTrackingPlan()
 
-	rulesOut, body, err := d.client.TrackingPlansAPI.ListRulesFromTrackingPlan(d.authContext, id).Pagination(*api.NewPaginationInput(MaxPageSize)).Execute()
-	if body != nil {
-		defer body.Body.Close()
-	}
+	rules, err := listTrackingPlanRules(d.authContext, d.client, id)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Unable to read Tracking Plan rules (ID: %s)", config.ID.ValueString()),
-			getError(err, body),
+			err.Error(),
+		)
+
+		return
+	}
+
+	rules, err = filterTrackingPlanRules(rules, config.RuleFilter)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rule_filter"),
+			"Invalid Tracking Plan rule filter",
+			err.Error(),
+		)
+
+		return
+	}
+
+	sourceIDs, err := listTrackingPlanSourceIDs(d.authContext, d.client, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Tracking Plan sources (ID: %s)", config.ID.ValueString()),
+			err.Error(),
 		)
 
 		return
 	}
 
 	var state models.TrackingPlanDSState
-	err = state.Fill(trackingPlan, &rulesOut.Data.Rules)
+	err = state.Fill(trackingPlan, &rules)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to populate Tracking Plan state",
@@ -175,6 +256,56 @@ func (d *trackingPlanDataSource) Read(ctx context.Context, req datasource.ReadRe
 
 		return
 	}
+	state.RuleFilter = config.RuleFilter
+	state.SourceIDs = []types.String{}
+	for _, sourceID := range sourceIDs {
+		state.SourceIDs = append(state.SourceIDs, types.StringValue(sourceID))
+	}
+
+	state.ExportTypewriterPlan = config.ExportTypewriterPlan
+	// The Workspace is only read for the export, which is named after its slug
+	if config.ExportTypewriterPlan.ValueBool() {
+		workspace, body, err := d.client.WorkspacesAPI.GetWorkspace(d.authContext).Execute()
+		if body != nil {
+			defer body.Body.Close()
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read Workspace",
+				getError(err, body),
+			)
+
+			return
+		}
+
+		typewriterPlan, err := buildTypewriterPlan(workspace.Data.Workspace.Slug, trackingPlan, rules)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to export Tracking Plan to Typewriter format",
+				err.Error(),
+			)
+
+			return
+		}
+		state.TypewriterPlan = types.StringValue(typewriterPlan)
+	}
+
+	state.GenerateTypeDefinitions = config.GenerateTypeDefinitions
+	for _, language := range config.GenerateTypeDefinitions {
+		definitions, err := generateTypeDefinitions(language.ValueString(), trackingPlan.Id, rules)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to generate Tracking Plan type definitions",
+				err.Error(),
+			)
+
+			return
+		}
+		if state.TypeDefinitions == nil {
+			state.TypeDefinitions = map[string]types.String{}
+		}
+		state.TypeDefinitions[language.ValueString()] = types.StringValue(definitions)
+	}
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -182,3 +313,85 @@ func (d *trackingPlanDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 }
+
+func listTrackingPlanRules(authContext context.Context, client *api.APIClient, id string) ([]api.RuleV1, error) {
+	rules := []api.RuleV1{}
+	paginationInput := *api.NewPaginationInput(MaxPageSize)
+
+	for {
+		out, body, err := client.TrackingPlansAPI.ListRulesFromTrackingPlan(authContext, id).Pagination(paginationInput).Execute()
+		if body != nil {
+			defer body.Body.Close()
+		}
+		if err != nil {
+			return nil, errors.New(getError(err, body))
+		}
+
+		rules = append(rules, out.Data.GetRules()...)
+
+		next := out.Data.GetPagination().Next.Get()
+		if next == nil {
+			return rules, nil
+		}
+		paginationInput.SetCursor(*next)
+	}
+}
+
+func listTrackingPlanSourceIDs(authContext context.Context, client *api.APIClient, id string) ([]string, error) {
+	sourceIDs := []string{}
+	paginationInput := *api.NewPaginationInput(MaxPageSize)
+
+	for {
+		out, body, err := client.TrackingPlansAPI.ListSourcesFromTrackingPlan(authContext, id).Pagination(paginationInput).Execute()
+		if body != nil {
+			defer body.Body.Close()
+		}
+		if err != nil {
+			return nil, errors.New(getError(err, body))
+		}
+
+		for _, source := range out.Data.GetSources() {
+			sourceIDs = append(sourceIDs, source.Id)
+		}
+
+		next := out.Data.GetPagination().Next.Get()
+		if next == nil {
+			return sourceIDs, nil
+		}
+		paginationInput.SetCursor(*next)
+	}
+}
+
+func filterTrackingPlanRules(rules []api.RuleV1, filter *models.RuleFilterDSState) ([]api.RuleV1, error) {
+	if filter == nil {
+		return rules, nil
+	}
+
+	var keyRegex *regexp.Regexp
+	if !filter.KeyRegex.IsNull() && !filter.KeyRegex.IsUnknown() {
+		var err error
+		keyRegex, err = regexp.Compile(filter.KeyRegex.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("invalid key_regex: %w", err)
+		}
+	}
+
+	filtered := []api.RuleV1{}
+	for _, rule := range rules {
+		if !filter.Type.IsNull() && rule.Type != filter.Type.ValueString() {
+			continue
+		}
+		if !filter.KeyPrefix.IsNull() && !strings.HasPrefix(rule.GetKey(), filter.KeyPrefix.ValueString()) {
+			continue
+		}
+		if keyRegex != nil && !keyRegex.MatchString(rule.GetKey()) {
+			continue
+		}
+		if !filter.Version.IsNull() && float64(rule.Version) != filter.Version.ValueFloat64() {
+			continue
+		}
+		filtered = append(filtered, rule)
+	}
+
+	return filtered, nil
+}
@@ -1,11 +1,13 @@
 package provider
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccTrackingPlanDataSource(t *testing.T) {
@@ -30,7 +32,13 @@ func TestAccTrackingPlanDataSource(t *testing.T) {
 										"properties": {
 											"context": {},
 											"traits": {},
-											"properties": {}
+											"properties": {
+												"type": "object",
+												"required": ["name"],
+												"properties": {
+													"name": {"type": "string"}
+												}
+											}
 										}
 									},
 									"createdAt": "2023-09-08T19:02:55.000Z",
@@ -45,6 +53,50 @@ func TestAccTrackingPlanDataSource(t *testing.T) {
 						}
 					}
 				`))
+				} else if req.URL.Path == "/tracking-plans/my-tracking-plan-id/sources" {
+					_, _ = w.Write([]byte(`
+					{
+						"data": {
+							"sources": [
+								{
+									"id": "my-source-id",
+									"slug": "my-source-slug",
+									"enabled": true,
+									"workspaceId": "my-workspace-id",
+									"writeKeys": [],
+									"metadata": {
+										"id": "my-metadata-id",
+										"slug": "javascript",
+										"name": "Javascript",
+										"categories": [],
+										"description": "",
+										"logos": {"default": ""},
+										"options": [],
+										"isCloudEventSource": false
+									},
+									"settings": {},
+									"labels": []
+								}
+							],
+							"pagination": {
+								"current": "MA==",
+								"totalEntries": 1
+							}
+						}
+					}
+				`))
+				} else if req.URL.Path == "/" {
+					_, _ = w.Write([]byte(`
+					{
+						"data": {
+							"workspace": {
+								"id": "my-workspace-id",
+								"name": "My workspace name",
+								"slug": "my-workspace-slug"
+							}
+						}
+					}
+				`))
 				} else if req.URL.Path == "/tracking-plans/my-tracking-plan-id" || req.URL.Path == "/tracking-plans" { // Tracking Plan requests
 					_, _ = w.Write([]byte(`
 					{
@@ -79,7 +131,13 @@ func TestAccTrackingPlanDataSource(t *testing.T) {
 			Steps: []resource.TestStep{
 				// Read testing
 				{
-					Config: providerConfig + `data "segment_tracking_plan" "test" { id = "my-tracking-plan-id" }`,
+					Config: providerConfig + `
+						data "segment_tracking_plan" "test" {
+							id                        = "my-tracking-plan-id"
+							export_typewriter_plan    = true
+							generate_type_definitions = ["typescript", "go"]
+						}
+					`,
 					Check: resource.ComposeAggregateTestCheckFunc(
 						resource.TestCheckResourceAttr("data.segment_tracking_plan.test", "id", "my-tracking-plan-id"),
 						resource.TestCheckResourceAttr("data.segment_tracking_plan.test", "name", "My Tracking Plan"),
@@ -95,7 +153,20 @@ func TestAccTrackingPlanDataSource(t *testing.T) {
 						resource.TestCheckResourceAttr("data.segment_tracking_plan.test", "rules.0.created_at", "2023-09-08T19:02:55.000Z"),
 						resource.TestCheckResourceAttr("data.segment_tracking_plan.test", "rules.0.updated_at", "2023-09-08T19:02:55.000Z"),
 						resource.TestCheckResourceAttr("data.segment_tracking_plan.test", "rules.0.deprecated_at", "0001-01-01T00:00:00.000Z"),
-						resource.TestCheckResourceAttr("data.segment_tracking_plan.test", "rules.0.json_schema", "{\"properties\":{\"context\":{},\"properties\":{},\"traits\":{}}}"),
+						resource.TestCheckResourceAttr("data.segment_tracking_plan.test", "rules.0.json_schema", "{\"properties\":{\"context\":{},\"properties\":{\"properties\":{\"name\":{\"type\":\"string\"}},\"required\":[\"name\"],\"type\":\"object\"},\"traits\":{}}}"),
+						resource.TestCheckResourceAttrWith("data.segment_tracking_plan.test", "typewriter_plan", func(value string) error {
+							expected := `{"name":"workspaces/my-workspace-slug/tracking-plans/my-tracking-plan-id","display_name":"My Tracking Plan","create_time":"2021-11-16T00:06:19.000Z","update_time":"2021-11-16T00:06:19.000Z","rules":{"events":[{"name":"Add Rule","version":1,"rules":{"properties":{"context":{},"properties":{"properties":{"name":{"type":"string"}},"required":["name"],"type":"object"},"traits":{}}}}]}}`
+							if !assert.JSONEq(t, expected, value) {
+								return fmt.Errorf("unexpected typewriter_plan: %s", value)
+							}
+
+							return nil
+						}),
+						resource.TestCheckResourceAttr("data.segment_tracking_plan.test", "source_ids.#", "1"),
+						resource.TestCheckResourceAttr("data.segment_tracking_plan.test", "source_ids.0", "my-source-id"),
+						resource.TestCheckResourceAttr("data.segment_tracking_plan.test", "type_definitions.%", "2"),
+						resource.TestCheckResourceAttr("data.segment_tracking_plan.test", "type_definitions.typescript", "// Generated by terraform-provider-segment from Tracking Plan my-tracking-plan-id. Do not edit.\n\nexport interface AddRule {\n  name: string\n}\n"),
+						resource.TestCheckResourceAttr("data.segment_tracking_plan.test", "type_definitions.go", "// Code generated by terraform-provider-segment from Tracking Plan my-tracking-plan-id. DO NOT EDIT.\n\npackage trackingplan\n\ntype AddRule struct {\n\tName string `json:\"name\"`\n}\n"),
 					),
 				},
 			},
@@ -131,6 +202,30 @@ func TestAccTrackingPlanDataSource(t *testing.T) {
 							}
 						}
 					`))
+				} else if req.URL.Path == "/tracking-plans/my-tracking-plan-id/sources" {
+					_, _ = w.Write([]byte(`
+						{
+							"data": {
+								"sources": [],
+								"pagination": {
+									"current": "MA==",
+									"totalEntries": 0
+								}
+							}
+						}
+					`))
+				} else if req.URL.Path == "/" {
+					_, _ = w.Write([]byte(`
+						{
+							"data": {
+								"workspace": {
+									"id": "my-workspace-id",
+									"name": "My workspace name",
+									"slug": "my-workspace-slug"
+								}
+							}
+						}
+					`))
 				} else if req.URL.Path == "/tracking-plans/my-tracking-plan-id" || req.URL.Path == "/tracking-plans" { // Tracking Plan requests
 					_, _ = w.Write([]byte(`
 						{
@@ -177,6 +272,139 @@ func TestAccTrackingPlanDataSource(t *testing.T) {
 						resource.TestCheckNoResourceAttr("data.segment_tracking_plan.test", "rules.0.created_at"),
 						resource.TestCheckNoResourceAttr("data.segment_tracking_plan.test", "rules.0.updated_at"),
 						resource.TestCheckNoResourceAttr("data.segment_tracking_plan.test", "rules.0.deprecated_at"),
+						resource.TestCheckNoResourceAttr("data.segment_tracking_plan.test", "typewriter_plan"),
+						resource.TestCheckResourceAttr("data.segment_tracking_plan.test", "source_ids.#", "0"),
+						resource.TestCheckNoResourceAttr("data.segment_tracking_plan.test", "type_definitions"),
+					),
+				},
+			},
+		})
+	})
+
+	t.Run("pagination and rule filter", func(t *testing.T) {
+		t.Parallel()
+		fakeServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.Header().Set("content-type", "application/json")
+				if req.URL.Path == "/tracking-plans/my-tracking-plan-id/rules" {
+					if req.URL.Query().Get("pagination[cursor]") == "next-page" {
+						_, _ = w.Write([]byte(`
+							{
+								"data": {
+									"rules": [
+										{"key": "Order Completed", "type": "TRACK", "version": 2, "jsonSchema": {}},
+										{"key": "Order Refunded", "type": "TRACK", "version": 1, "jsonSchema": {}}
+									],
+									"pagination": {
+										"current": "next-page",
+										"previous": "MA==",
+										"totalEntries": 4
+									}
+								}
+							}
+						`))
+					} else {
+						_, _ = w.Write([]byte(`
+							{
+								"data": {
+									"rules": [
+										{"type": "IDENTIFY", "version": 1, "jsonSchema": {}},
+										{"key": "Order Completed", "type": "TRACK", "version": 1, "jsonSchema": {}}
+									],
+									"pagination": {
+										"current": "MA==",
+										"next": "next-page",
+										"totalEntries": 4
+									}
+								}
+							}
+						`))
+					}
+				} else if req.URL.Path == "/tracking-plans/my-tracking-plan-id/sources" {
+					_, _ = w.Write([]byte(`
+						{
+							"data": {
+								"sources": [],
+								"pagination": {
+									"current": "MA==",
+									"totalEntries": 0
+								}
+							}
+						}
+					`))
+				} else if req.URL.Path == "/" {
+					_, _ = w.Write([]byte(`
+						{
+							"data": {
+								"workspace": {
+									"id": "my-workspace-id",
+									"name": "My workspace name",
+									"slug": "my-workspace-slug"
+								}
+							}
+						}
+					`))
+				} else if req.URL.Path == "/tracking-plans/my-tracking-plan-id" {
+					_, _ = w.Write([]byte(`
+						{
+							"data": {
+								"trackingPlan": {
+									"id": "my-tracking-plan-id",
+									"type": "LIVE"
+								}
+							}
+						}
+					`))
+				}
+			}),
+		)
+		defer fakeServer.Close()
+
+		providerConfig := `
+			provider "segment" {
+				url   = "` + fakeServer.URL + `"
+				token = "abc123"
+			}
+		`
+
+		resource.Test(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: providerConfig + `data "segment_tracking_plan" "test" { id = "my-tracking-plan-id" }`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.segment_tracking_plan.test", "rules.#", "4"),
+					),
+				},
+				{
+					Config: providerConfig + `
+						data "segment_tracking_plan" "test" {
+							id = "my-tracking-plan-id"
+							rule_filter = {
+								type       = "TRACK"
+								key_prefix = "Order"
+								key_regex  = "Completed$"
+							}
+						}
+					`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.segment_tracking_plan.test", "rules.#", "2"),
+						resource.TestCheckTypeSetElemNestedAttrs("data.segment_tracking_plan.test", "rules.*", map[string]string{"key": "Order Completed", "version": "1"}),
+						resource.TestCheckTypeSetElemNestedAttrs("data.segment_tracking_plan.test", "rules.*", map[string]string{"key": "Order Completed", "version": "2"}),
+					),
+				},
+				{
+					Config: providerConfig + `
+						data "segment_tracking_plan" "test" {
+							id = "my-tracking-plan-id"
+							rule_filter = {
+								version = 2
+							}
+						}
+					`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.segment_tracking_plan.test", "rules.#", "1"),
+						resource.TestCheckResourceAttr("data.segment_tracking_plan.test", "rules.0.version", "2"),
 					),
 				},
 			},
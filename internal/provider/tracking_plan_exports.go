@@ -0,0 +1,526 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/segmentio/public-api-sdk-go/api"
+)
+
+const (
+	TypeDefinitionsTypeScript = "typescript"
+	TypeDefinitionsGo         = "go"
+)
+
+type typewriterPlanExport struct {
+	Name        string                `json:"name"`
+	DisplayName string                `json:"display_name"`
+	Rules       typewriterRulesExport `json:"rules"`
+	CreateTime  string                `json:"create_time,omitempty"`
+	UpdateTime  string                `json:"update_time,omitempty"`
+}
+
+type typewriterRulesExport struct {
+	Global   interface{}             `json:"global,omitempty"`
+	Identify interface{}             `json:"identify,omitempty"`
+	Group    interface{}             `json:"group,omitempty"`
+	Events   []typewriterEventExport `json:"events"`
+}
+
+type typewriterEventExport struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Version     float32     `json:"version"`
+	Rules       interface{} `json:"rules"`
+}
+
+// buildTypewriterPlan renders a Tracking Plan and its rules in the Typewriter plan.json format.
+func buildTypewriterPlan(workspaceSlug string, trackingPlan api.TrackingPlanV1, rules []api.RuleV1) (string, error) {
+	plan := typewriterPlanExport{
+		Name:        fmt.Sprintf("workspaces/%s/tracking-plans/%s", workspaceSlug, trackingPlan.Id),
+		DisplayName: trackingPlan.GetName(),
+		CreateTime:  trackingPlan.GetCreatedAt(),
+		UpdateTime:  trackingPlan.GetUpdatedAt(),
+		Rules: typewriterRulesExport{
+			Events: []typewriterEventExport{},
+		},
+	}
+
+	for _, rule := range rules {
+		switch rule.Type {
+		case "COMMON":
+			plan.Rules.Global = rule.JsonSchema
+		case "IDENTIFY":
+			plan.Rules.Identify = rule.JsonSchema
+		case "GROUP":
+			plan.Rules.Group = rule.JsonSchema
+		case "TRACK":
+			event := typewriterEventExport{
+				Name:    rule.GetKey(),
+				Version: rule.Version,
+				Rules:   rule.JsonSchema,
+			}
+			if schema, ok := rule.JsonSchema.(map[string]interface{}); ok {
+				if description, ok := schema["description"].(string); ok {
+					event.Description = description
+				}
+			}
+			plan.Rules.Events = append(plan.Rules.Events, event)
+		}
+	}
+
+	out, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("could not marshal json: %w", err)
+	}
+
+	return string(out), nil
+}
+
+// typeDefinition is an object type extracted from a rule's JSON Schema.
+type typeDefinition struct {
+	name        string
+	description string
+	properties  []typeProperty
+}
+
+type typeProperty struct {
+	name        string
+	description string
+	required    bool
+	schema      map[string]interface{}
+	// nestedType is set when the property is an object (or list of objects) with its own type definition.
+	nestedType string
+}
+
+type typeDefinitionBuilder struct {
+	definitions []*typeDefinition
+	usedNames   map[string]bool
+}
+
+// generateTypeDefinitions generates type definitions in the given language for the payload of every TRACK, IDENTIFY and GROUP rule.
+func generateTypeDefinitions(language string, trackingPlanID string, rules []api.RuleV1) (string, error) {
+	builder := typeDefinitionBuilder{usedNames: map[string]bool{}}
+
+	for _, rule := range rules {
+		var name, section string
+		switch rule.Type {
+		case "TRACK":
+			name, section = pascalCase(rule.GetKey()), "properties"
+		case "IDENTIFY":
+			name, section = "IdentifyTraits", "traits"
+		case "GROUP":
+			name, section = "GroupTraits", "traits"
+		default:
+			continue
+		}
+		if rule.Version > 1 {
+			name += "V" + strconv.FormatFloat(float64(rule.Version), 'f', -1, 32)
+		}
+
+		schema, _ := rule.JsonSchema.(map[string]interface{})
+		payload := map[string]interface{}{}
+		if properties, ok := schema["properties"].(map[string]interface{}); ok {
+			if p, ok := properties[section].(map[string]interface{}); ok {
+				payload = p
+			}
+		}
+
+		description, _ := schema["description"].(string)
+		builder.add(name, description, payload)
+	}
+
+	switch language {
+	case TypeDefinitionsTypeScript:
+		return builder.typeScript(trackingPlanID), nil
+	case TypeDefinitionsGo:
+		return builder.golang(trackingPlanID)
+	default:
+		return "", fmt.Errorf("unsupported language %q", language)
+	}
+}
+
+func (b *typeDefinitionBuilder) add(name string, description string, schema map[string]interface{}) string {
+	if name == "" {
+		name = "Type"
+	}
+	// Identifiers cannot start with a digit, as in events like '2FA Enabled'
+	if unicode.IsDigit(rune(name[0])) {
+		name = "X" + name
+	}
+	unique := name
+	for i := 2; b.usedNames[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	b.usedNames[unique] = true
+
+	definition := &typeDefinition{name: unique, description: description}
+	b.definitions = append(b.definitions, definition)
+
+	required := map[string]bool{}
+	if list, ok := schema["required"].([]interface{}); ok {
+		for _, r := range list {
+			if s, ok := r.(string); ok {
+				required[s] = true
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	names := make([]string, 0, len(properties))
+	for propertyName := range properties {
+		names = append(names, propertyName)
+	}
+	sort.Strings(names)
+
+	for _, propertyName := range names {
+		propertySchema, _ := properties[propertyName].(map[string]interface{})
+		property := typeProperty{
+			name:     propertyName,
+			required: required[propertyName],
+			schema:   propertySchema,
+		}
+		property.description, _ = propertySchema["description"].(string)
+
+		objectSchema := propertySchema
+		if hasSchemaType(propertySchema, "array") {
+			objectSchema, _ = propertySchema["items"].(map[string]interface{})
+		}
+		if _, ok := objectSchema["properties"].(map[string]interface{}); ok && hasSchemaType(objectSchema, "object") {
+			property.nestedType = b.add(unique+pascalCase(propertyName), property.description, objectSchema)
+		}
+
+		definition.properties = append(definition.properties, property)
+	}
+
+	return unique
+}
+
+func (b *typeDefinitionBuilder) typeScript(trackingPlanID string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "// Generated by terraform-provider-segment from Tracking Plan %s. Do not edit.\n", trackingPlanID)
+
+	for _, definition := range b.definitions {
+		sb.WriteString("\n")
+		sb.WriteString(typeScriptComment("", definition.description))
+		fmt.Fprintf(&sb, "export interface %s {\n", definition.name)
+		for _, property := range definition.properties {
+			sb.WriteString(typeScriptComment("  ", property.description))
+			optional := ""
+			if !property.required {
+				optional = "?"
+			}
+			fmt.Fprintf(&sb, "  %s%s: %s\n", typeScriptKey(property.name), optional, typeScriptType(property.schema, property.nestedType))
+		}
+		sb.WriteString("}\n")
+	}
+
+	return sb.String()
+}
+
+func (b *typeDefinitionBuilder) golang(trackingPlanID string) (string, error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "// Code generated by terraform-provider-segment from Tracking Plan %s. DO NOT EDIT.\n\npackage trackingplan\n", trackingPlanID)
+
+	for _, definition := range b.definitions {
+		sb.WriteString("\n")
+		if definition.description != "" {
+			sb.WriteString(goComment("", definition.name+" "+definition.description))
+		}
+		fmt.Fprintf(&sb, "type %s struct {\n", definition.name)
+		// Property names like 'order_id' and 'orderId' have the same field name
+		usedFieldNames := map[string]bool{}
+		for _, property := range definition.properties {
+			sb.WriteString(goComment("\t", property.description))
+			tag := property.name
+			if !property.required {
+				tag += ",omitempty"
+			}
+			fieldName := goFieldName(property.name)
+			unique := fieldName
+			for i := 2; usedFieldNames[unique]; i++ {
+				unique = fieldName + strconv.Itoa(i)
+			}
+			usedFieldNames[unique] = true
+			fmt.Fprintf(&sb, "\t%s %s `json:%q`\n", unique, goType(property.schema, property.nestedType, property.required), tag)
+		}
+		sb.WriteString("}\n")
+	}
+
+	formatted, err := format.Source([]byte(sb.String()))
+	if err != nil {
+		return "", fmt.Errorf("could not format Go type definitions: %w", err)
+	}
+
+	return string(formatted), nil
+}
+
+// commentLines splits a description into the lines of a comment.
+func commentLines(description string) []string {
+	return strings.Split(strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(strings.TrimSpace(description)), "\n")
+}
+
+// typeScriptComment renders a description as a JSDoc comment, which cannot contain the '*/' terminator.
+func typeScriptComment(indent string, description string) string {
+	if strings.TrimSpace(description) == "" {
+		return ""
+	}
+
+	lines := commentLines(strings.ReplaceAll(description, "*/", "*\\/"))
+	if len(lines) == 1 {
+		return fmt.Sprintf("%s/** %s */\n", indent, lines[0])
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s/**\n", indent)
+	for _, line := range lines {
+		fmt.Fprintf(&sb, "%s%s\n", indent, strings.TrimRight(" * "+line, " "))
+	}
+	fmt.Fprintf(&sb, "%s */\n", indent)
+
+	return sb.String()
+}
+
+// goComment renders a description as Go line comments.
+func goComment(indent string, description string) string {
+	if strings.TrimSpace(description) == "" {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, line := range commentLines(description) {
+		fmt.Fprintf(&sb, "%s%s\n", indent, strings.TrimRight("// "+line, " "))
+	}
+
+	return sb.String()
+}
+
+func schemaTypes(schema map[string]interface{}) []string {
+	switch t := schema["type"].(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		out := []string{}
+		for _, v := range t {
+			if s, ok := v.(string); ok {
+				out = append(out, s)
+			}
+		}
+
+		return out
+	default:
+		return nil
+	}
+}
+
+func hasSchemaType(schema map[string]interface{}, schemaType string) bool {
+	for _, t := range schemaTypes(schema) {
+		if t == schemaType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func typeScriptType(schema map[string]interface{}, nestedType string) string {
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		values := []string{}
+		for _, v := range enum {
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				continue
+			}
+			values = append(values, string(encoded))
+		}
+
+		return strings.Join(values, " | ")
+	}
+
+	schemaTypes := schemaTypes(schema)
+	if len(schemaTypes) == 0 {
+		return "any"
+	}
+
+	out := []string{}
+	for _, t := range schemaTypes {
+		switch t {
+		case "string", "boolean", "number", "null":
+			out = append(out, t)
+		case "integer":
+			out = append(out, "number")
+		case "array":
+			items, _ := schema["items"].(map[string]interface{})
+			itemType := typeScriptType(items, nestedType)
+			if strings.Contains(itemType, " | ") {
+				itemType = "(" + itemType + ")"
+			}
+			out = append(out, itemType+"[]")
+		case "object":
+			if nestedType != "" {
+				out = append(out, nestedType)
+			} else {
+				out = append(out, "Record<string, any>")
+			}
+		default:
+			out = append(out, "any")
+		}
+	}
+
+	return strings.Join(out, " | ")
+}
+
+func goType(schema map[string]interface{}, nestedType string, required bool) string {
+	nullable := false
+	nonNull := []string{}
+	for _, t := range schemaTypes(schema) {
+		if t == "null" {
+			nullable = true
+		} else {
+			nonNull = append(nonNull, t)
+		}
+	}
+	if len(nonNull) != 1 {
+		return "interface{}"
+	}
+
+	var out string
+	switch nonNull[0] {
+	case "string":
+		out = "string"
+	case "integer":
+		out = "int64"
+	case "number":
+		out = "float64"
+	case "boolean":
+		out = "bool"
+	case "array":
+		items, _ := schema["items"].(map[string]interface{})
+		return "[]" + goType(items, nestedType, true)
+	case "object":
+		if nestedType == "" {
+			return "map[string]interface{}"
+		}
+		out = nestedType
+	default:
+		return "interface{}"
+	}
+
+	if nullable || !required {
+		return "*" + out
+	}
+
+	return out
+}
+
+func typeScriptKey(name string) string {
+	for i, r := range name {
+		if !(r == '_' || r == '$' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return strconv.Quote(name)
+		}
+	}
+	if name == "" {
+		return `""`
+	}
+
+	return name
+}
+
+func goFieldName(name string) string {
+	out := pascalCase(name)
+	if out == "" || unicode.IsDigit(rune(out[0])) {
+		out = "X" + out
+	}
+
+	return out
+}
+
+// pascalCase converts free-form names like 'Order Completed' or 'order_id' into 'OrderCompleted' and 'OrderId'.
+func pascalCase(name string) string {
+	var sb strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		runes := []rune(word)
+		sb.WriteRune(unicode.ToUpper(runes[0]))
+		sb.WriteString(string(runes[1:]))
+	}
+
+	return sb.String()
+}
+
+// trackingPlanFQL holds FQL statements derived from the rules of a Tracking Plan.
+type trackingPlanFQL struct {
+	TrackEvents          string
+	UnplannedTrackEvents string
+	Planned              string
+	Unplanned            string
+}
+
+// buildTrackingPlanFQL derives FQL statements matching the events planned by the given rules. Statements that would match nothing are left empty.
+func buildTrackingPlanFQL(rules []api.RuleV1) trackingPlanFQL {
+	names := map[string][]string{}
+	seen := map[string]bool{}
+	typesWithoutKey := map[string]bool{}
+	for _, rule := range rules {
+		ruleType := strings.ToLower(rule.Type)
+		if ruleType == "common" {
+			continue
+		}
+		if rule.GetKey() == "" || ruleType == "identify" || ruleType == "group" {
+			typesWithoutKey[ruleType] = true
+
+			continue
+		}
+		if seen[ruleType+"\x00"+rule.GetKey()] {
+			continue
+		}
+		seen[ruleType+"\x00"+rule.GetKey()] = true
+		names[ruleType] = append(names[ruleType], rule.GetKey())
+	}
+
+	var fql trackingPlanFQL
+	trackEvents := fqlAnyOf("event", names["track"])
+	if trackEvents != "" {
+		fql.TrackEvents = trackEvents
+		fql.UnplannedTrackEvents = fmt.Sprintf("type = \"track\" and !(%s)", trackEvents)
+	} else if !typesWithoutKey["track"] {
+		fql.UnplannedTrackEvents = "type = \"track\""
+	}
+
+	statements := []string{}
+	for _, ruleType := range []string{"track", "identify", "group", "page", "screen"} {
+		field := "event"
+		if ruleType == "page" || ruleType == "screen" {
+			field = "name"
+		}
+
+		if typesWithoutKey[ruleType] {
+			statements = append(statements, fmt.Sprintf("type = %s", fqlString(ruleType)))
+		} else if len(names[ruleType]) > 0 {
+			statements = append(statements, fmt.Sprintf("(type = %s and (%s))", fqlString(ruleType), fqlAnyOf(field, names[ruleType])))
+		}
+	}
+	if len(statements) > 0 {
+		fql.Planned = strings.Join(statements, " or ")
+		fql.Unplanned = fmt.Sprintf("!(%s)", fql.Planned)
+	}
+
+	return fql
+}
+
+func fqlAnyOf(field string, values []string) string {
+	statements := make([]string, 0, len(values))
+	for _, value := range values {
+		statements = append(statements, fmt.Sprintf("%s = %s", field, fqlString(value)))
+	}
+
+	return strings.Join(statements, " or ")
+}
+
+func fqlString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
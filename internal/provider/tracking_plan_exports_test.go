@@ -0,0 +1,285 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/segmentio/public-api-sdk-go/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTrackingPlanRules() []api.RuleV1 {
+	key := "Order Completed"
+
+	return []api.RuleV1{
+		{
+			Type:    "COMMON",
+			Version: 1,
+			JsonSchema: map[string]interface{}{
+				"properties": map[string]interface{}{"context": map[string]interface{}{}},
+			},
+		},
+		{
+			Type:    "TRACK",
+			Key:     &key,
+			Version: 1,
+			JsonSchema: map[string]interface{}{
+				"description": "An order was completed",
+				"properties": map[string]interface{}{
+					"properties": map[string]interface{}{
+						"type":     "object",
+						"required": []interface{}{"order_id", "products"},
+						"properties": map[string]interface{}{
+							"order_id": map[string]interface{}{"type": "string", "description": "The order id"},
+							"total":    map[string]interface{}{"type": []interface{}{"number", "null"}},
+							"currency": map[string]interface{}{"type": "string", "enum": []interface{}{"USD", "EUR"}},
+							"products": map[string]interface{}{
+								"type": "array",
+								"items": map[string]interface{}{
+									"type":       "object",
+									"properties": map[string]interface{}{"sku": map[string]interface{}{"type": "string"}},
+								},
+							},
+							"coupon code": map[string]interface{}{},
+						},
+					},
+				},
+			},
+		},
+		{
+			Type:    "TRACK",
+			Key:     &key,
+			Version: 2,
+		},
+		{
+			Type:    "IDENTIFY",
+			Version: 1,
+			JsonSchema: map[string]interface{}{
+				"properties": map[string]interface{}{
+					"traits": map[string]interface{}{
+						"properties": map[string]interface{}{"email": map[string]interface{}{"type": "string"}},
+						"required":   []interface{}{"email"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildTypewriterPlan(t *testing.T) {
+	t.Parallel()
+
+	name := "My Tracking Plan"
+	plan, err := buildTypewriterPlan("my-workspace", api.TrackingPlanV1{Id: "tp_123", Name: &name, Type: "LIVE"}, testTrackingPlanRules())
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": "workspaces/my-workspace/tracking-plans/tp_123",
+		"display_name": "My Tracking Plan",
+		"rules": {
+			"global": {"properties": {"context": {}}},
+			"identify": {"properties": {"traits": {"properties": {"email": {"type": "string"}}, "required": ["email"]}}},
+			"events": [
+				{
+					"name": "Order Completed",
+					"description": "An order was completed",
+					"version": 1,
+					"rules": {
+						"description": "An order was completed",
+						"properties": {
+							"properties": {
+								"type": "object",
+								"required": ["order_id", "products"],
+								"properties": {
+									"order_id": {"type": "string", "description": "The order id"},
+									"total": {"type": ["number", "null"]},
+									"currency": {"type": "string", "enum": ["USD", "EUR"]},
+									"products": {"type": "array", "items": {"type": "object", "properties": {"sku": {"type": "string"}}}},
+									"coupon code": {}
+								}
+							}
+						}
+					}
+				},
+				{"name": "Order Completed", "version": 2, "rules": null}
+			]
+		}
+	}`, plan)
+}
+
+func TestGenerateTypeDefinitions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("typescript", func(t *testing.T) {
+		t.Parallel()
+		out, err := generateTypeDefinitions(TypeDefinitionsTypeScript, "tp_123", testTrackingPlanRules())
+
+		require.NoError(t, err)
+		assert.Equal(t, `// Generated by terraform-provider-segment from Tracking Plan tp_123. Do not edit.
+
+/** An order was completed */
+export interface OrderCompleted {
+  "coupon code"?: any
+  currency?: "USD" | "EUR"
+  /** The order id */
+  order_id: string
+  products: OrderCompletedProducts[]
+  total?: number | null
+}
+
+export interface OrderCompletedProducts {
+  sku?: string
+}
+
+export interface OrderCompletedV2 {
+}
+
+export interface IdentifyTraits {
+  email: string
+}
+`, out)
+	})
+
+	t.Run("go", func(t *testing.T) {
+		t.Parallel()
+		out, err := generateTypeDefinitions(TypeDefinitionsGo, "tp_123", testTrackingPlanRules())
+
+		require.NoError(t, err)
+		assert.Equal(t, "// Code generated by terraform-provider-segment from Tracking Plan tp_123. DO NOT EDIT.\n\n"+
+			"package trackingplan\n\n"+
+			"// OrderCompleted An order was completed\n"+
+			"type OrderCompleted struct {\n"+
+			"\tCouponCode interface{} `json:\"coupon code,omitempty\"`\n"+
+			"\tCurrency   *string     `json:\"currency,omitempty\"`\n"+
+			"\t// The order id\n"+
+			"\tOrderId  string                   `json:\"order_id\"`\n"+
+			"\tProducts []OrderCompletedProducts `json:\"products\"`\n"+
+			"\tTotal    *float64                 `json:\"total,omitempty\"`\n"+
+			"}\n\n"+
+			"type OrderCompletedProducts struct {\n"+
+			"\tSku *string `json:\"sku,omitempty\"`\n"+
+			"}\n\n"+
+			"type OrderCompletedV2 struct {\n"+
+			"}\n\n"+
+			"type IdentifyTraits struct {\n"+
+			"\tEmail string `json:\"email\"`\n"+
+			"}\n", out)
+	})
+
+	t.Run("names starting with a digit", func(t *testing.T) {
+		t.Parallel()
+		rules := []api.RuleV1{{
+			Type:       "TRACK",
+			Key:        api.PtrString("2FA Enabled"),
+			Version:    1,
+			JsonSchema: map[string]interface{}{"properties": map[string]interface{}{"properties": map[string]interface{}{}}},
+		}}
+		out, err := generateTypeDefinitions(TypeDefinitionsGo, "tp_123", rules)
+
+		require.NoError(t, err)
+		assert.Contains(t, out, "type X2FAEnabled struct {\n}\n")
+	})
+
+	t.Run("multi-line descriptions and colliding names", func(t *testing.T) {
+		t.Parallel()
+		rules := []api.RuleV1{
+			{
+				Type:    "TRACK",
+				Key:     api.PtrString("Order Completed"),
+				Version: 1,
+				JsonSchema: map[string]interface{}{
+					"description": "An order was completed.\nSent by the */checkout service.",
+					"properties": map[string]interface{}{
+						"properties": map[string]interface{}{
+							"properties": map[string]interface{}{
+								"order_id": map[string]interface{}{"type": "string", "description": "The order id\r\nas a string"},
+								"orderId":  map[string]interface{}{"type": "string"},
+							},
+						},
+					},
+				},
+			},
+			{
+				Type:       "TRACK",
+				Key:        api.PtrString("order_completed"),
+				Version:    1,
+				JsonSchema: map[string]interface{}{"properties": map[string]interface{}{"properties": map[string]interface{}{}}},
+			},
+		}
+
+		out, err := generateTypeDefinitions(TypeDefinitionsGo, "tp_123", rules)
+
+		require.NoError(t, err)
+		assert.Equal(t, "// Code generated by terraform-provider-segment from Tracking Plan tp_123. DO NOT EDIT.\n\n"+
+			"package trackingplan\n\n"+
+			"// OrderCompleted An order was completed.\n"+
+			"// Sent by the */checkout service.\n"+
+			"type OrderCompleted struct {\n"+
+			"\tOrderId *string `json:\"orderId,omitempty\"`\n"+
+			"\t// The order id\n"+
+			"\t// as a string\n"+
+			"\tOrderId2 *string `json:\"order_id,omitempty\"`\n"+
+			"}\n\n"+
+			"type OrderCompleted2 struct {\n"+
+			"}\n", out)
+
+		out, err = generateTypeDefinitions(TypeDefinitionsTypeScript, "tp_123", rules)
+
+		require.NoError(t, err)
+		assert.Equal(t, `// Generated by terraform-provider-segment from Tracking Plan tp_123. Do not edit.
+
+/**
+ * An order was completed.
+ * Sent by the *\/checkout service.
+ */
+export interface OrderCompleted {
+  orderId?: string
+  /**
+   * The order id
+   * as a string
+   */
+  order_id?: string
+}
+
+export interface OrderCompleted2 {
+}
+`, out)
+	})
+
+	t.Run("unsupported language", func(t *testing.T) {
+		t.Parallel()
+		_, err := generateTypeDefinitions("python", "tp_123", testTrackingPlanRules())
+		require.Error(t, err)
+	})
+}
+
+func TestBuildTrackingPlanFQL(t *testing.T) {
+	t.Parallel()
+
+	t.Run("type-aware statements", func(t *testing.T) {
+		t.Parallel()
+		page := "Home"
+		quoted := `Clicked "Buy"`
+		rules := append(testTrackingPlanRules(),
+			api.RuleV1{Type: "PAGE", Key: &page, Version: 1},
+			api.RuleV1{Type: "TRACK", Key: &quoted, Version: 1},
+		)
+
+		fql := buildTrackingPlanFQL(rules)
+
+		assert.Equal(t, `event = "Order Completed" or event = "Clicked \"Buy\""`, fql.TrackEvents)
+		assert.Equal(t, `type = "track" and !(event = "Order Completed" or event = "Clicked \"Buy\"")`, fql.UnplannedTrackEvents)
+		assert.Equal(t, `(type = "track" and (event = "Order Completed" or event = "Clicked \"Buy\"")) or type = "identify" or (type = "page" and (name = "Home"))`, fql.Planned)
+		assert.Equal(t, `!((type = "track" and (event = "Order Completed" or event = "Clicked \"Buy\"")) or type = "identify" or (type = "page" and (name = "Home")))`, fql.Unplanned)
+	})
+
+	t.Run("no rules", func(t *testing.T) {
+		t.Parallel()
+		fql := buildTrackingPlanFQL([]api.RuleV1{{Type: "COMMON", Version: 1}})
+
+		assert.Empty(t, fql.TrackEvents)
+		assert.Equal(t, `type = "track"`, fql.UnplannedTrackEvents)
+		assert.Empty(t, fql.Planned)
+		assert.Empty(t, fql.Unplanned)
+	})
+}
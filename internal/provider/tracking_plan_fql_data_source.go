@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/segmentio/public-api-sdk-go/api"
+)
+
+var (
+	_ datasource.DataSource              = &trackingPlanFQLDataSource{}
+	_ datasource.DataSourceWithConfigure = &trackingPlanFQLDataSource{}
+)
+
+type trackingPlanFQLDataSource struct {
+	client      *api.APIClient
+	authContext context.Context
+}
+
+func NewTrackingPlanFQLDataSource() datasource.DataSource {
+	return &trackingPlanFQLDataSource{}
+}
+
+func (d *trackingPlanFQLDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected ClientInfo, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = config.client
+	d.authContext = config.authContext
+}
+
+func (d *trackingPlanFQLDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tracking_plan_fql"
+}
+
+func (d *trackingPlanFQLDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Derives [FQL](https://segment.com/docs/api/public-api/fql/) statements from the rules of a Tracking Plan, to be used as the `if` of a `segment_destination_filter` or the `trigger` of a `segment_destination_subscription`. " +
+			"Statements that would not match any event are left empty.",
+		Attributes: map[string]schema.Attribute{
+			"tracking_plan_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The Tracking Plan's identifier.",
+			},
+			"track_events": schema.StringAttribute{
+				Computed:    true,
+				Description: "Matches the events of the Tracking Plan's TRACK rules, for example `event = \"A\" or event = \"B\"`.",
+			},
+			"unplanned_track_events": schema.StringAttribute{
+				Computed:    true,
+				Description: "Matches track calls for events that are not in the Tracking Plan.",
+			},
+			"planned": schema.StringAttribute{
+				Computed: true,
+				Description: "Matches any call planned by the Tracking Plan: track calls by `event`, page and screen calls by `name`, " +
+					"and identify and group calls by `type` when the Tracking Plan has IDENTIFY or GROUP rules.",
+			},
+			"unplanned": schema.StringAttribute{
+				Computed:    true,
+				Description: "The negation of 'planned'. Use it with a DROP action in a Destination filter to drop anything not in the Tracking Plan.",
+			},
+		},
+	}
+}
+
+func (d *trackingPlanFQLDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config models.TrackingPlanFQLDSState
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := config.TrackingPlanID.ValueString()
+	if id == "" {
+		resp.Diagnostics.AddError("Unable to read Tracking Plan", "ID is empty")
+
+		return
+	}
+
+	rules, err := listTrackingPlanRules(d.authContext, d.client, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Tracking Plan rules (ID: %s)", id),
+			err.Error(),
+		)
+
+		return
+	}
+
+	fql := buildTrackingPlanFQL(rules)
+
+	state := models.TrackingPlanFQLDSState{
+		TrackingPlanID:       config.TrackingPlanID,
+		TrackEvents:          optionalStringValue(fql.TrackEvents),
+		UnplannedTrackEvents: optionalStringValue(fql.UnplannedTrackEvents),
+		Planned:              optionalStringValue(fql.Planned),
+		Unplanned:            optionalStringValue(fql.Unplanned),
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func optionalStringValue(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value)
+}
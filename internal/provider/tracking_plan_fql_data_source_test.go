@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTrackingPlanFQLDataSource(t *testing.T) {
+	t.Parallel()
+
+	fakeServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("content-type", "application/json")
+
+			if req.URL.Path == "/tracking-plans/my-tracking-plan-id/rules" {
+				_, _ = w.Write([]byte(`
+					{
+						"data": {
+							"rules": [
+								{"type": "COMMON", "version": 1, "jsonSchema": {}},
+								{"type": "IDENTIFY", "version": 1, "jsonSchema": {}},
+								{"key": "Order Completed", "type": "TRACK", "version": 1, "jsonSchema": {}},
+								{"key": "Order Completed", "type": "TRACK", "version": 2, "jsonSchema": {}},
+								{"key": "Signed Up", "type": "TRACK", "version": 1, "jsonSchema": {}}
+							],
+							"pagination": {
+								"current": "MA==",
+								"totalEntries": 5
+							}
+						}
+					}
+				`))
+			}
+		}),
+	)
+	defer fakeServer.Close()
+
+	providerConfig := `
+		provider "segment" {
+			url   = "` + fakeServer.URL + `"
+			token = "abc123"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `data "segment_tracking_plan_fql" "test" { tracking_plan_id = "my-tracking-plan-id" }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.segment_tracking_plan_fql.test", "tracking_plan_id", "my-tracking-plan-id"),
+					resource.TestCheckResourceAttr("data.segment_tracking_plan_fql.test", "track_events", `event = "Order Completed" or event = "Signed Up"`),
+					resource.TestCheckResourceAttr("data.segment_tracking_plan_fql.test", "unplanned_track_events", `type = "track" and !(event = "Order Completed" or event = "Signed Up")`),
+					resource.TestCheckResourceAttr("data.segment_tracking_plan_fql.test", "planned", `(type = "track" and (event = "Order Completed" or event = "Signed Up")) or type = "identify"`),
+					resource.TestCheckResourceAttr("data.segment_tracking_plan_fql.test", "unplanned", `!((type = "track" and (event = "Order Completed" or event = "Signed Up")) or type = "identify")`),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,529 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
+)
+
+var (
+	_ function.Function = &rulesFromTypewriterFunction{}
+	_ function.Function = &rulesFromProtocolsJSONFunction{}
+	_ function.Function = &rulesFromProtocolsCSVFunction{}
+)
+
+const jsonSchemaDraft07 = "http://json-schema.org/draft-07/schema#"
+
+// rulesReturn matches the nested object of the `rules` attribute in the segment_tracking_plan resource.
+var rulesReturn = function.ListReturn{
+	ElementType: types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"type":        types.StringType,
+			"key":         types.StringType,
+			"json_schema": jsontypes.NormalizedType{},
+			"version":     types.Float64Type,
+		},
+	},
+}
+
+const rulesReturnDescription = "The returned list matches the shape of the `rules` attribute of `segment_tracking_plan` " +
+	"(`type`, `key`, `json_schema` and `version`), so it can be assigned to it directly."
+
+func NewRulesFromTypewriterFunction() function.Function {
+	return &rulesFromTypewriterFunction{}
+}
+
+type rulesFromTypewriterFunction struct{}
+
+func (f *rulesFromTypewriterFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "rules_from_typewriter"
+}
+
+func (f *rulesFromTypewriterFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Converts a Typewriter plan.json into Tracking Plan rules.",
+		MarkdownDescription: "Parses a Typewriter `plan.json` file into Tracking Plan rules. The `global` rule becomes a `COMMON` rule, " +
+			"`identify` and `group` become `IDENTIFY` and `GROUP` rules, and every entry of `events` becomes a `TRACK` rule keyed by its name. " +
+			rulesReturnDescription,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "plan",
+				MarkdownDescription: "The contents of a Typewriter `plan.json` file, for example `file(\"plan.json\")`.",
+			},
+		},
+		Return: rulesReturn,
+	}
+}
+
+func (f *rulesFromTypewriterFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var plan string
+	resp.Error = req.Arguments.Get(ctx, &plan)
+	if resp.Error != nil {
+		return
+	}
+
+	rules, err := parseTypewriterPlan([]byte(plan))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Unable to parse Typewriter plan: %s", err.Error()))
+
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, rules)
+}
+
+func NewRulesFromProtocolsJSONFunction() function.Function {
+	return &rulesFromProtocolsJSONFunction{}
+}
+
+type rulesFromProtocolsJSONFunction struct{}
+
+func (f *rulesFromProtocolsJSONFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "rules_from_protocols_json"
+}
+
+func (f *rulesFromProtocolsJSONFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Converts a Protocols JSON export into Tracking Plan rules.",
+		MarkdownDescription: "Parses a Protocols JSON export into Tracking Plan rules. Both the legacy export format (with `rules.global`, `rules.events`, `rules.identify` and `rules.group`) " +
+			"and the Public API format (a `rules` list, optionally wrapped in `data`, of objects with `type`, `key`, `version` and `jsonSchema`) are supported. " +
+			rulesReturnDescription,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "export",
+				MarkdownDescription: "The contents of a Protocols JSON export, for example `file(\"tracking-plan.json\")`.",
+			},
+		},
+		Return: rulesReturn,
+	}
+}
+
+func (f *rulesFromProtocolsJSONFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var export string
+	resp.Error = req.Arguments.Get(ctx, &export)
+	if resp.Error != nil {
+		return
+	}
+
+	rules, err := parseProtocolsJSON([]byte(export))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Unable to parse Protocols JSON export: %s", err.Error()))
+
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, rules)
+}
+
+func NewRulesFromProtocolsCSVFunction() function.Function {
+	return &rulesFromProtocolsCSVFunction{}
+}
+
+type rulesFromProtocolsCSVFunction struct{}
+
+func (f *rulesFromProtocolsCSVFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "rules_from_protocols_csv"
+}
+
+func (f *rulesFromProtocolsCSVFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Converts a Protocols CSV export into Tracking Plan rules.",
+		MarkdownDescription: "Parses a Protocols CSV export into Tracking Plan rules. Each row describes one property of an event, and rows sharing the same event type and name are merged into a single rule. " +
+			"The header row must contain `Event Name`, and may contain `Event Type` (defaults to `TRACK`), `Event Description`, `Event Version`, `Property Name`, `Property Description`, " +
+			"`Property Type` (comma or pipe separated, for example `string, null`), `Required` (`true`/`yes`), `Enum Values` (comma separated) and `Pattern`. " +
+			"Properties of `IDENTIFY` and `GROUP` events are added to `traits`, all others to `properties`. " +
+			rulesReturnDescription,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "export",
+				MarkdownDescription: "The contents of a Protocols CSV export, for example `file(\"tracking-plan.csv\")`.",
+			},
+		},
+		Return: rulesReturn,
+	}
+}
+
+func (f *rulesFromProtocolsCSVFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var export string
+	resp.Error = req.Arguments.Get(ctx, &export)
+	if resp.Error != nil {
+		return
+	}
+
+	rules, err := parseProtocolsCSV(export)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Unable to parse Protocols CSV export: %s", err.Error()))
+
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, rules)
+}
+
+type typewriterPlan struct {
+	Rules *typewriterRules `json:"rules"`
+}
+
+type typewriterRules struct {
+	Global   map[string]interface{} `json:"global"`
+	Identify map[string]interface{} `json:"identify"`
+	Group    map[string]interface{} `json:"group"`
+	Events   []typewriterEvent      `json:"events"`
+}
+
+type typewriterEvent struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Version     float64                `json:"version"`
+	Rules       map[string]interface{} `json:"rules"`
+}
+
+func parseTypewriterPlan(data []byte) ([]models.RulesState, error) {
+	var plan typewriterPlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, err
+	}
+	if plan.Rules == nil {
+		return nil, errors.New("missing `rules` object")
+	}
+
+	rules := []models.RulesState{}
+	for _, r := range []struct {
+		ruleType string
+		schema   map[string]interface{}
+	}{
+		{"COMMON", plan.Rules.Global},
+		{"IDENTIFY", plan.Rules.Identify},
+		{"GROUP", plan.Rules.Group},
+	} {
+		if len(r.schema) == 0 {
+			continue
+		}
+		rule, err := newRulesState(r.ruleType, nil, r.schema, 1)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	for i, event := range plan.Rules.Events {
+		if event.Name == "" {
+			return nil, fmt.Errorf("event at index %d has no name", i)
+		}
+
+		schema := event.Rules
+		if schema == nil {
+			schema = newRuleSchema("TRACK", "", nil, nil)
+		}
+		if _, ok := schema["description"]; !ok && event.Description != "" {
+			schema["description"] = event.Description
+		}
+
+		name := event.Name
+		rule, err := newRulesState("TRACK", &name, schema, event.Version)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+type protocolsJSONExport struct {
+	Data  *protocolsJSONExport `json:"data"`
+	Rules json.RawMessage      `json:"rules"`
+}
+
+type protocolsJSONRule struct {
+	Type       string                 `json:"type"`
+	Key        *string                `json:"key"`
+	Version    float64                `json:"version"`
+	JSONSchema map[string]interface{} `json:"jsonSchema"`
+}
+
+func parseProtocolsJSON(data []byte) ([]models.RulesState, error) {
+	var export protocolsJSONExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, err
+	}
+	if export.Data != nil {
+		export = *export.Data
+	}
+
+	trimmed := bytes.TrimSpace(export.Rules)
+	if len(trimmed) == 0 {
+		return nil, errors.New("missing `rules`")
+	}
+
+	// The legacy export format matches the Typewriter plan.json format.
+	if trimmed[0] == '{' {
+		return parseTypewriterPlan(data)
+	}
+
+	var apiRules []protocolsJSONRule
+	if err := json.Unmarshal(trimmed, &apiRules); err != nil {
+		return nil, err
+	}
+
+	rules := []models.RulesState{}
+	for i, r := range apiRules {
+		if r.Type == "" {
+			return nil, fmt.Errorf("rule at index %d has no type", i)
+		}
+		schema := r.JSONSchema
+		if schema == nil {
+			schema = newRuleSchema(r.Type, "", nil, nil)
+		}
+		rule, err := newRulesState(r.Type, r.Key, schema, r.Version)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+var csvHeaderSanitizer = regexp.MustCompile(`[^a-z]`)
+
+// csvColumns maps normalized CSV header names to the field they describe.
+var csvColumns = map[string]string{
+	"eventtype":           "event_type",
+	"type":                "event_type",
+	"eventname":           "event_name",
+	"event":               "event_name",
+	"eventdescription":    "event_description",
+	"eventversion":        "event_version",
+	"version":             "event_version",
+	"propertyname":        "property_name",
+	"property":            "property_name",
+	"propertydescription": "property_description",
+	"propertytype":        "property_type",
+	"required":            "required",
+	"propertyrequired":    "required",
+	"enumvalues":          "enum",
+	"propertyenumvalues":  "enum",
+	"allowedvalues":       "enum",
+	"enum":                "enum",
+	"pattern":             "pattern",
+	"propertypattern":     "pattern",
+	"regex":               "pattern",
+}
+
+type csvEvent struct {
+	ruleType    string
+	name        string
+	description string
+	version     float64
+	properties  map[string]interface{}
+	required    []string
+}
+
+func parseProtocolsCSV(data string) ([]models.RulesState, error) {
+	reader := csv.NewReader(strings.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("missing header row")
+		}
+
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		normalized := csvHeaderSanitizer.ReplaceAllString(strings.ToLower(name), "")
+		if field, ok := csvColumns[normalized]; ok {
+			if _, exists := columns[field]; !exists {
+				columns[field] = i
+			}
+		}
+	}
+	if _, ok := columns["event_name"]; !ok {
+		return nil, errors.New("header row must contain an `Event Name` column")
+	}
+
+	events := []*csvEvent{}
+	eventsByKey := map[string]*csvEvent{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		get := func(field string) string {
+			i, ok := columns[field]
+			if !ok || i >= len(record) {
+				return ""
+			}
+
+			return strings.TrimSpace(record[i])
+		}
+
+		name := get("event_name")
+		if name == "" {
+			continue
+		}
+		ruleType := strings.ToUpper(get("event_type"))
+		if ruleType == "" {
+			ruleType = "TRACK"
+		}
+
+		event, ok := eventsByKey[ruleType+"\x00"+name]
+		if !ok {
+			event = &csvEvent{ruleType: ruleType, name: name, version: 1, properties: map[string]interface{}{}}
+			eventsByKey[ruleType+"\x00"+name] = event
+			events = append(events, event)
+		}
+		if description := get("event_description"); description != "" {
+			event.description = description
+		}
+		if version := get("event_version"); version != "" {
+			v, err := strconv.ParseFloat(version, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid version %q", line, version)
+			}
+			event.version = v
+		}
+
+		propertyName := get("property_name")
+		if propertyName == "" {
+			continue
+		}
+
+		property := map[string]interface{}{}
+		if description := get("property_description"); description != "" {
+			property["description"] = description
+		}
+		if propertyTypes := splitCSVList(get("property_type")); len(propertyTypes) == 1 {
+			property["type"] = strings.ToLower(propertyTypes[0])
+		} else if len(propertyTypes) > 1 {
+			lowered := make([]interface{}, len(propertyTypes))
+			for i, t := range propertyTypes {
+				lowered[i] = strings.ToLower(t)
+			}
+			property["type"] = lowered
+		}
+		if enum := splitCSVList(get("enum")); len(enum) > 0 {
+			values := make([]interface{}, len(enum))
+			for i, e := range enum {
+				values[i] = e
+			}
+			property["enum"] = values
+		}
+		if pattern := get("pattern"); pattern != "" {
+			property["pattern"] = pattern
+		}
+		event.properties[propertyName] = property
+
+		switch strings.ToLower(get("required")) {
+		case "true", "yes", "y", "1", "required":
+			event.required = append(event.required, propertyName)
+		}
+	}
+
+	rules := []models.RulesState{}
+	for _, event := range events {
+		var key *string
+		if event.ruleType != "IDENTIFY" && event.ruleType != "GROUP" && event.ruleType != "COMMON" {
+			name := event.name
+			key = &name
+		}
+
+		schema := newRuleSchema(event.ruleType, event.description, event.properties, event.required)
+		rule, err := newRulesState(event.ruleType, key, schema, event.version)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func splitCSVList(value string) []string {
+	out := []string{}
+	for _, v := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '|' }) {
+		if v = strings.TrimSpace(v); v != "" && !strings.EqualFold(v, "any") {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
+
+// newRuleSchema builds a Tracking Plan rule JSON Schema, placing properties under `traits` for IDENTIFY and GROUP rules and under `properties` otherwise.
+func newRuleSchema(ruleType string, description string, properties map[string]interface{}, required []string) map[string]interface{} {
+	section := map[string]interface{}{}
+	if len(properties) > 0 {
+		section["type"] = "object"
+		section["properties"] = properties
+	}
+	if len(required) > 0 {
+		section["required"] = required
+	}
+
+	sectionName := "properties"
+	if ruleType == "IDENTIFY" || ruleType == "GROUP" {
+		sectionName = "traits"
+	}
+
+	schemaProperties := map[string]interface{}{
+		"context":    map[string]interface{}{},
+		"traits":     map[string]interface{}{},
+		"properties": map[string]interface{}{},
+	}
+	schemaProperties[sectionName] = section
+
+	schema := map[string]interface{}{
+		"$schema":    jsonSchemaDraft07,
+		"type":       "object",
+		"properties": schemaProperties,
+	}
+	if description != "" {
+		schema["description"] = description
+	}
+	if len(required) > 0 {
+		schema["required"] = []string{sectionName}
+	}
+
+	return schema
+}
+
+func newRulesState(ruleType string, key *string, schema map[string]interface{}, version float64) (models.RulesState, error) {
+	jsonSchema, err := json.Marshal(schema)
+	if err != nil {
+		return models.RulesState{}, fmt.Errorf("could not marshal json: %w", err)
+	}
+
+	if version == 0 {
+		version = 1
+	}
+
+	return models.RulesState{
+		Type:       types.StringValue(ruleType),
+		Key:        types.StringPointerValue(key),
+		JSONSchema: jsontypes.NewNormalizedValue(string(jsonSchema)),
+		Version:    types.Float64Value(version),
+	}, nil
+}
@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTypewriterPlan(t *testing.T) {
+	t.Parallel()
+
+	t.Run("converts global, identify, group and events", func(t *testing.T) {
+		t.Parallel()
+		rules, err := parseTypewriterPlan([]byte(`
+		{
+			"name": "workspaces/my-workspace/tracking-plans/rs_123",
+			"display_name": "My Plan",
+			"rules": {
+				"global": {"$schema": "http://json-schema.org/draft-07/schema#", "type": "object", "properties": {"context": {}}},
+				"identify": {"$schema": "http://json-schema.org/draft-07/schema#", "type": "object", "properties": {"traits": {"type": "object"}}},
+				"group": {},
+				"events": [
+					{
+						"name": "Order Completed",
+						"description": "Fired when an order is completed",
+						"version": 2,
+						"rules": {"$schema": "http://json-schema.org/draft-07/schema#", "type": "object", "properties": {"properties": {"type": "object", "properties": {"total": {"type": "number"}}}}}
+					},
+					{"name": "Empty Event"}
+				]
+			}
+		}`))
+
+		require.NoError(t, err)
+		require.Len(t, rules, 4)
+
+		assert.Equal(t, "COMMON", rules[0].Type.ValueString())
+		assert.True(t, rules[0].Key.IsNull())
+		assert.Equal(t, "IDENTIFY", rules[1].Type.ValueString())
+
+		assert.Equal(t, "TRACK", rules[2].Type.ValueString())
+		assert.Equal(t, "Order Completed", rules[2].Key.ValueString())
+		assert.InDelta(t, 2, rules[2].Version.ValueFloat64(), 0)
+		assert.JSONEq(t, `{"$schema":"http://json-schema.org/draft-07/schema#","description":"Fired when an order is completed","type":"object","properties":{"properties":{"type":"object","properties":{"total":{"type":"number"}}}}}`, rules[2].JSONSchema.ValueString())
+
+		assert.Equal(t, "Empty Event", rules[3].Key.ValueString())
+		assert.InDelta(t, 1, rules[3].Version.ValueFloat64(), 0)
+		assert.JSONEq(t, `{"$schema":"http://json-schema.org/draft-07/schema#","type":"object","properties":{"context":{},"traits":{},"properties":{}}}`, rules[3].JSONSchema.ValueString())
+	})
+
+	t.Run("errors without rules", func(t *testing.T) {
+		t.Parallel()
+		_, err := parseTypewriterPlan([]byte(`{"name": "plan"}`))
+		require.Error(t, err)
+	})
+
+	t.Run("errors on unnamed events", func(t *testing.T) {
+		t.Parallel()
+		_, err := parseTypewriterPlan([]byte(`{"rules": {"events": [{"version": 1}]}}`))
+		require.Error(t, err)
+	})
+}
+
+func TestParseProtocolsJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("public api format", func(t *testing.T) {
+		t.Parallel()
+		rules, err := parseProtocolsJSON([]byte(`
+		{
+			"data": {
+				"rules": [
+					{"type": "TRACK", "key": "Add Rule", "version": 1, "jsonSchema": {"properties": {"context": {}, "traits": {}, "properties": {}}}},
+					{"type": "IDENTIFY", "version": 3, "jsonSchema": {"properties": {"traits": {}}}}
+				]
+			}
+		}`))
+
+		require.NoError(t, err)
+		require.Len(t, rules, 2)
+		assert.Equal(t, "Add Rule", rules[0].Key.ValueString())
+		assert.JSONEq(t, `{"properties":{"context":{},"properties":{},"traits":{}}}`, rules[0].JSONSchema.ValueString())
+		assert.Equal(t, "IDENTIFY", rules[1].Type.ValueString())
+		assert.True(t, rules[1].Key.IsNull())
+		assert.InDelta(t, 3, rules[1].Version.ValueFloat64(), 0)
+	})
+
+	t.Run("legacy format", func(t *testing.T) {
+		t.Parallel()
+		rules, err := parseProtocolsJSON([]byte(`{"rules": {"events": [{"name": "Signed Up", "version": 1}]}}`))
+
+		require.NoError(t, err)
+		require.Len(t, rules, 1)
+		assert.Equal(t, "TRACK", rules[0].Type.ValueString())
+		assert.Equal(t, "Signed Up", rules[0].Key.ValueString())
+	})
+
+	t.Run("errors without type", func(t *testing.T) {
+		t.Parallel()
+		_, err := parseProtocolsJSON([]byte(`{"rules": [{"key": "Add Rule"}]}`))
+		require.Error(t, err)
+	})
+}
+
+func TestParseProtocolsCSV(t *testing.T) {
+	t.Parallel()
+
+	t.Run("groups rows into rules", func(t *testing.T) {
+		t.Parallel()
+		rules, err := parseProtocolsCSV(`Event Type,Event Name,Event Description,Property Name,Property Description,Property Type,Required,Enum Values,Pattern
+Track,Order Completed,An order was completed,total,Order total,number,true,,
+Track,Order Completed,,currency,,"string, null",false,"USD,EUR",
+Identify,Identify,,email,,string,yes,,^.+@.+$
+Track,Signed Up,,,,,,,
+`)
+
+		require.NoError(t, err)
+		require.Len(t, rules, 3)
+
+		assert.Equal(t, "TRACK", rules[0].Type.ValueString())
+		assert.Equal(t, "Order Completed", rules[0].Key.ValueString())
+		assert.JSONEq(t, `{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"type": "object",
+			"description": "An order was completed",
+			"required": ["properties"],
+			"properties": {
+				"context": {},
+				"traits": {},
+				"properties": {
+					"type": "object",
+					"required": ["total"],
+					"properties": {
+						"total": {"type": "number", "description": "Order total"},
+						"currency": {"type": ["string", "null"], "enum": ["USD", "EUR"]}
+					}
+				}
+			}
+		}`, rules[0].JSONSchema.ValueString())
+
+		assert.Equal(t, "IDENTIFY", rules[1].Type.ValueString())
+		assert.True(t, rules[1].Key.IsNull())
+		assert.JSONEq(t, `{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"type": "object",
+			"required": ["traits"],
+			"properties": {
+				"context": {},
+				"properties": {},
+				"traits": {
+					"type": "object",
+					"required": ["email"],
+					"properties": {
+						"email": {"type": "string", "pattern": "^.+@.+$"}
+					}
+				}
+			}
+		}`, rules[1].JSONSchema.ValueString())
+
+		assert.Equal(t, "Signed Up", rules[2].Key.ValueString())
+		assert.InDelta(t, 1, rules[2].Version.ValueFloat64(), 0)
+	})
+
+	t.Run("errors without event name column", func(t *testing.T) {
+		t.Parallel()
+		_, err := parseProtocolsCSV("Property Name,Property Type\nfoo,string\n")
+		require.Error(t, err)
+	})
+
+	t.Run("errors on invalid version", func(t *testing.T) {
+		t.Parallel()
+		_, err := parseProtocolsCSV("Event Name,Version\nfoo,abc\n")
+		require.Error(t, err)
+	})
+}
+
+func TestAccTrackingPlanFunctions(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					locals {
+						typewriter = provider::segment::rules_from_typewriter(jsonencode({
+							rules = {
+								events = [{ name = "Order Completed", version = 1, rules = { type = "object" } }]
+							}
+						}))
+						protocols_json = provider::segment::rules_from_protocols_json(jsonencode({
+							rules = [{ type = "IDENTIFY", version = 2, jsonSchema = { type = "object" } }]
+						}))
+						protocols_csv = provider::segment::rules_from_protocols_csv("Event Name\nSigned Up\n")
+					}
+
+					output "typewriter_count" { value = length(local.typewriter) }
+					output "typewriter_type" { value = local.typewriter[0].type }
+					output "typewriter_key" { value = local.typewriter[0].key }
+					output "typewriter_json_schema" { value = local.typewriter[0].json_schema }
+					output "protocols_json_type" { value = local.protocols_json[0].type }
+					output "protocols_json_version" { value = local.protocols_json[0].version }
+					output "protocols_csv_key" { value = local.protocols_csv[0].key }
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("typewriter_count", "1"),
+					resource.TestCheckOutput("typewriter_type", "TRACK"),
+					resource.TestCheckOutput("typewriter_key", "Order Completed"),
+					resource.TestCheckOutput("typewriter_json_schema", `{"type":"object"}`),
+					resource.TestCheckOutput("protocols_json_type", "IDENTIFY"),
+					resource.TestCheckOutput("protocols_json_version", "2"),
+					resource.TestCheckOutput("protocols_csv_key", "Signed Up"),
+				),
+			},
+		},
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
@@ -64,3 +65,17 @@ func mergeSettings(configSettings, remoteSettings jsontypes.Normalized, isWareho
 
 	return result, nil
 }
+
+// keyedMutex serializes the operations sharing a key, such as the creations of write keys of a Source.
+type keyedMutex struct {
+	mutexes sync.Map
+}
+
+// Lock locks the mutex of the key and returns the function unlocking it.
+func (m *keyedMutex) Lock(key string) func() {
+	value, _ := m.mutexes.LoadOrStore(key, &sync.Mutex{})
+	mutex := value.(*sync.Mutex)
+	mutex.Lock()
+
+	return mutex.Unlock
+}
@@ -1,7 +1,10 @@
 package provider
 
 import (
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/stretchr/testify/assert"
@@ -264,3 +267,45 @@ func TestMergeSettings(t *testing.T) {
 		assert.Equal(t, "configValue2", resultMap["key2"])
 	})
 }
+
+func TestKeyedMutex(t *testing.T) {
+	t.Parallel()
+
+	var mutex keyedMutex
+	var wg sync.WaitGroup
+	keys := []string{"source-a", "source-b"}
+	// The counters are only protected by the keyed mutex, so the race detector reports them if it does not lock
+	counters := map[string]*int{"source-a": new(int), "source-b": new(int)}
+	running := map[string]*atomic.Int32{"source-a": {}, "source-b": {}}
+	maxRunning := map[string]*atomic.Int32{"source-a": {}, "source-b": {}}
+
+	for i := 0; i < 20; i++ {
+		key := keys[i%2]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := mutex.Lock(key)
+			defer unlock()
+
+			current := running[key].Add(1)
+			for {
+				previous := maxRunning[key].Load()
+				if current <= previous || maxRunning[key].CompareAndSwap(previous, current) {
+					break
+				}
+			}
+
+			counter := *counters[key]
+			time.Sleep(time.Millisecond)
+			*counters[key] = counter + 1
+
+			running[key].Add(-1)
+		}()
+	}
+	wg.Wait()
+
+	for _, key := range keys {
+		assert.Equal(t, int32(1), maxRunning[key].Load(), key)
+		assert.Equal(t, 10, *counters[key], key)
+	}
+}
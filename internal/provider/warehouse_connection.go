@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/segmentio/public-api-sdk-go/api"
+)
+
+// warehouseConnectionFailure is a reason why Segment could not connect to a Warehouse. Setting is empty when the failure
+// can not be attributed to a single setting.
+type warehouseConnectionFailure struct {
+	Setting string
+	Message string
+}
+
+// testWarehouseConnection asks Segment to connect to a Warehouse with the given settings. It returns the connection status and
+// the failures reported by Segment, or an error when the validation itself could not be performed.
+func testWarehouseConnection(authContext context.Context, client *api.APIClient, metadataID string, settings map[string]interface{}) (string, []warehouseConnectionFailure, error) {
+	out, body, err := client.WarehousesAPI.CreateValidationInWarehouse(authContext).CreateValidationInWarehouseV1Input(api.CreateValidationInWarehouseV1Input{
+		MetadataId: metadataID,
+		Settings:   settings,
+	}).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		if body == nil || (body.StatusCode != http.StatusBadRequest && body.StatusCode != http.StatusUnprocessableEntity) {
+			return "", nil, errors.New(getError(err, body))
+		}
+
+		var envelope api.RequestErrorEnvelope
+		content, readErr := io.ReadAll(body.Body)
+		if readErr != nil || json.Unmarshal(content, &envelope) != nil || len(envelope.Errors) == 0 {
+			return "", nil, fmt.Errorf("%w\n%s", err, content)
+		}
+
+		failures := []warehouseConnectionFailure{}
+		for _, requestError := range envelope.Errors {
+			message := requestError.GetMessage()
+			if message == "" {
+				message = requestError.Type
+			}
+			failures = append(failures, warehouseConnectionFailure{
+				Setting: findFailingSetting(requestError.GetField(), message, settings),
+				Message: message,
+			})
+		}
+
+		return "", failures, nil
+	}
+
+	status := out.Data.GetStatus()
+	if normalizedStatus := strings.ToUpper(status); strings.Contains(normalizedStatus, "FAIL") || strings.Contains(normalizedStatus, "INVALID") {
+		return status, []warehouseConnectionFailure{{Message: fmt.Sprintf("Segment reported the connection status %s.", status)}}, nil
+	}
+
+	return status, nil, nil
+}
+
+// findFailingSetting returns the setting named by the field of an error, or else the first setting mentioned in its message.
+func findFailingSetting(field string, message string, settings map[string]interface{}) string {
+	field = strings.TrimPrefix(field, "settings.")
+	if _, ok := settings[field]; ok {
+		return field
+	}
+
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		if regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(name) + `\b`).MatchString(message) {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// validateWarehouseConnection returns an error diagnostic on `settings` for every reason Segment could not connect to a Warehouse.
+func validateWarehouseConnection(authContext context.Context, client *api.APIClient, metadataID string, settings map[string]interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	_, failures, err := testWarehouseConnection(authContext, client, metadataID, settings)
+	if err != nil {
+		diags.AddError("Unable to validate Warehouse connection", err.Error())
+
+		return diags
+	}
+
+	for _, failure := range failures {
+		detail := failure.Message
+		if failure.Setting != "" {
+			detail = fmt.Sprintf("Setting %q: %s", failure.Setting, failure.Message)
+		}
+		diags.AddAttributeError(
+			path.Root("settings"),
+			"Unable to connect to Warehouse",
+			detail+"\n\nSet `validate_connection` to false to skip this check.",
+		)
+	}
+
+	return diags
+}
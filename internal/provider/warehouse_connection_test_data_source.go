@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/segmentio/public-api-sdk-go/api"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
+)
+
+var (
+	_ datasource.DataSource              = &warehouseConnectionTestDataSource{}
+	_ datasource.DataSourceWithConfigure = &warehouseConnectionTestDataSource{}
+)
+
+func NewWarehouseConnectionTestDataSource() datasource.DataSource {
+	return &warehouseConnectionTestDataSource{}
+}
+
+type warehouseConnectionTestDataSource struct {
+	client      *api.APIClient
+	authContext context.Context
+}
+
+func (d *warehouseConnectionTestDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_warehouse_connection_test"
+}
+
+func (d *warehouseConnectionTestDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Checks that Segment can connect to a Warehouse with the given settings. A failed connection does not fail the read, so the result can be asserted in `check` blocks on every plan. For more information, visit the [Segment docs](https://segment.com/docs/connections/storage/).",
+		Attributes: map[string]schema.Attribute{
+			"metadata_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The id of the Warehouse metadata, such as `segment_warehouse.example.metadata.id`.",
+			},
+			"settings": schema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Description: "The connection settings to check, such as host, username, and password.",
+				CustomType:  jsontypes.NormalizedType{},
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The connection status reported by Segment. Empty when the connection failed.",
+			},
+			"valid": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether Segment could connect to the Warehouse.",
+			},
+			"errors": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The reasons why Segment could not connect to the Warehouse.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"setting": schema.StringAttribute{
+							Computed:    true,
+							Description: "The setting that caused the failure, if it could be determined.",
+						},
+						"message": schema.StringAttribute{
+							Computed:    true,
+							Description: "The failure reported by Segment.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *warehouseConnectionTestDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state models.WarehouseConnectionTestState
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var settings map[string]interface{}
+	diags = state.Settings.Unmarshal(&settings)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	status, failures, err := testWarehouseConnection(d.authContext, d.client, state.MetadataID.ValueString(), settings)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to validate Warehouse connection", err.Error())
+
+		return
+	}
+
+	state.Status = types.StringValue(status)
+	state.Valid = types.BoolValue(len(failures) == 0)
+	state.Errors = []models.WarehouseConnectionTestError{}
+	for _, failure := range failures {
+		setting := types.StringNull()
+		if failure.Setting != "" {
+			setting = types.StringValue(failure.Setting)
+		}
+		state.Errors = append(state.Errors, models.WarehouseConnectionTestError{
+			Setting: setting,
+			Message: types.StringValue(failure.Message),
+		})
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (d *warehouseConnectionTestDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientInfo, ok := req.ProviderData.(*ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ClientInfo, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = clientInfo.client
+	d.authContext = clientInfo.authContext
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccWarehouseConnectionTestDataSource(t *testing.T) {
+	t.Parallel()
+
+	fakeServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("content-type", "application/json")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`
+				{
+					"errors": [
+						{
+							"type": "validation",
+							"message": "Could not authenticate: the password is incorrect."
+						}
+					]
+				}
+			`))
+		}),
+	)
+	defer fakeServer.Close()
+
+	providerConfig := `
+		provider "segment" {
+			url   = "` + fakeServer.URL + `"
+			token = "abc123"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+					data "segment_warehouse_connection_test" "test" {
+						metadata_id = "my-metadata-id"
+						settings = jsonencode({
+							"host": "example.com",
+							"password": "wrong"
+						})
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.segment_warehouse_connection_test.test", "valid", "false"),
+					resource.TestCheckResourceAttr("data.segment_warehouse_connection_test.test", "status", ""),
+					resource.TestCheckResourceAttr("data.segment_warehouse_connection_test.test", "errors.#", "1"),
+					resource.TestCheckResourceAttr("data.segment_warehouse_connection_test.test", "errors.0.setting", "password"),
+					resource.TestCheckResourceAttr("data.segment_warehouse_connection_test.test", "errors.0.message", "Could not authenticate: the password is incorrect."),
+				),
+			},
+		},
+	})
+}
@@ -66,7 +66,7 @@ func (d *warehouseDataSource) Schema(_ context.Context, _ datasource.SchemaReque
 }
 
 func (d *warehouseDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var state models.WarehouseState
+	var state models.WarehouseDataSourceState
 
 	diags := req.Config.Get(ctx, &state)
 
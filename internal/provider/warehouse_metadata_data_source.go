@@ -9,8 +9,12 @@ import (
 	"github.com/segmentio/public-api-sdk-go/api"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -28,6 +32,7 @@ func NewWarehouseMetadataDataSource() datasource.DataSource {
 type warehouseMetadataDataSource struct {
 	client      *api.APIClient
 	authContext context.Context
+	catalog     *catalogCache
 }
 
 func warehouseMetadataSchema() map[string]schema.Attribute {
@@ -118,6 +123,20 @@ func (d *warehouseMetadataDataSource) Read(ctx context.Context, req datasource.R
 		return
 	}
 
+	if state.ID.IsNull() {
+		id, err := findWarehouseMetadataID(d.authContext, d.client, d.catalog, state.Slug.ValueString(), "")
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("slug"),
+				"Unable to find Warehouse metadata",
+				err.Error(),
+			)
+
+			return
+		}
+		state.ID = types.StringValue(id)
+	}
+
 	id := state.ID.ValueString()
 	if id == "" {
 		resp.Diagnostics.AddError("Unable to read Warehouse metadata", "ID is empty")
@@ -125,7 +144,7 @@ func (d *warehouseMetadataDataSource) Read(ctx context.Context, req datasource.R
 		return
 	}
 
-	response, body, err := d.client.CatalogAPI.GetWarehouseMetadata(d.authContext, state.ID.ValueString()).Execute()
+	response, body, err := d.client.CatalogAPI.GetWarehouseMetadata(d.authContext, id).Execute()
 	if body != nil {
 		defer body.Body.Close()
 	}
@@ -159,9 +178,24 @@ func (d *warehouseMetadataDataSource) Read(ctx context.Context, req datasource.R
 
 // Schema defines the schema for the data source.
 func (d *warehouseMetadataDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := warehouseMetadataSchema()
+	attributes["id"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The id of this object. Exactly one of `id` or `slug` must be set.",
+		Validators: []validator.String{
+			stringvalidator.ExactlyOneOf(path.MatchRoot("slug")),
+		},
+	}
+	attributes["slug"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "A human-readable, unique identifier for object. Can be set instead of `id` to look up the Warehouse metadata in the Segment catalog.",
+	}
+
 	resp.Schema = schema.Schema{
 		Description: "Reads a Warehouse metadata. For more information, visit the [Segment docs](https://segment.com/docs/connections/storage/catalog/).",
-		Attributes:  warehouseMetadataSchema(),
+		Attributes:  attributes,
 	}
 }
 
@@ -183,4 +217,5 @@ func (d *warehouseMetadataDataSource) Configure(_ context.Context, req datasourc
 
 	d.client = clientInfo.client
 	d.authContext = clientInfo.authContext
+	d.catalog = clientInfo.catalog
 }
@@ -3,6 +3,7 @@ package provider
 import (
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -145,4 +146,70 @@ func TestAccWarehouseMetadataDataSource(t *testing.T) {
 			},
 		})
 	})
+
+	t.Run("slug lookup", func(t *testing.T) {
+		t.Parallel()
+		fakeServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.Header().Set("content-type", "application/json")
+
+				metadata := `
+					{
+						"id": "my-warehouse-metadata-id",
+						"slug": "my-warehouse-metadata-slug",
+						"name": "The name of the warehouse metadata",
+						"description": "The description of a warehouse metadata",
+						"logos": {
+							"default": "the default value of a logo"
+						},
+						"options": []
+					}
+				`
+				if req.URL.Path == "/catalog/warehouses" {
+					_, _ = w.Write([]byte(`
+						{
+							"data": {
+								"warehousesCatalog": [` + metadata + `],
+								"pagination": {
+									"current": "MA==",
+									"totalEntries": 1
+								}
+							}
+						}
+					`))
+
+					return
+				}
+
+				_, _ = w.Write([]byte(`{"data": {"warehouseMetadata": ` + metadata + `}}`))
+			}),
+		)
+		defer fakeServer.Close()
+
+		providerConfig := `
+			provider "segment" {
+				url   = "` + fakeServer.URL + `"
+				token = "abc123"
+			}
+		`
+
+		resource.Test(t, resource.TestCase{
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				// Read testing
+				{
+					Config: providerConfig + `data "segment_warehouse_metadata" "test" { slug = "my-warehouse-metadata-slug" }`,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("data.segment_warehouse_metadata.test", "id", "my-warehouse-metadata-id"),
+						resource.TestCheckResourceAttr("data.segment_warehouse_metadata.test", "slug", "my-warehouse-metadata-slug"),
+					),
+				},
+				// Unknown slugs are reported
+				{
+					Config:      providerConfig + `data "segment_warehouse_metadata" "test" { slug = "my-unknown-slug" }`,
+					ExpectError: regexp.MustCompile(`no Warehouse with slug "my-unknown-slug"`),
+				},
+			},
+		})
+	})
 }
@@ -8,16 +8,20 @@ import (
 	"github.com/segmentio/terraform-provider-segment/internal/provider/docs"
 	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
 
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/segmentio/public-api-sdk-go/api"
 )
@@ -26,6 +30,7 @@ var (
 	_ resource.Resource                = &warehouseResource{}
 	_ resource.ResourceWithConfigure   = &warehouseResource{}
 	_ resource.ResourceWithImportState = &warehouseResource{}
+	_ resource.ResourceWithModifyPlan  = &warehouseResource{}
 )
 
 func NewWarehouseResource() resource.Resource {
@@ -35,6 +40,7 @@ func NewWarehouseResource() resource.Resource {
 type warehouseResource struct {
 	client      *api.APIClient
 	authContext context.Context
+	catalog     *catalogCache
 }
 
 func (r *warehouseResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -61,22 +67,25 @@ func (r *warehouseResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 				Attributes: map[string]schema.Attribute{
 					"id": schema.StringAttribute{
-						Required:    true,
-						Description: "The id of this object.",
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
+						Optional:    true,
+						Computed:    true,
+						Description: "The id of this object. Exactly one of `id`, `slug` or `name` must be set. The Warehouse is only replaced when the resolved id changes.",
+						Validators: []validator.String{
+							stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("slug"), path.MatchRelative().AtParent().AtName("name")),
 						},
 					},
 					"name": schema.StringAttribute{
+						Optional:    true,
 						Computed:    true,
-						Description: "The name of this object.",
+						Description: "The name of this object. Can be set instead of `id` to look up the Warehouse metadata in the Segment catalog.",
 						PlanModifiers: []planmodifier.String{
 							stringplanmodifier.UseStateForUnknown(),
 						},
 					},
 					"slug": schema.StringAttribute{
+						Optional:    true,
 						Computed:    true,
-						Description: "A human-readable, unique identifier for object.",
+						Description: "A human-readable, unique identifier for object. Can be set instead of `id` to look up the Warehouse metadata in the Segment catalog.",
 						PlanModifiers: []planmodifier.String{
 							stringplanmodifier.UseStateForUnknown(),
 						},
@@ -175,10 +184,22 @@ func (r *warehouseResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Description: "The settings associated with this Warehouse. Common settings are connection-related configuration used to connect to it, for example host, username, and port. Only settings included in the configuration will be managed by Terraform.",
 				CustomType:  jsontypes.NormalizedType{},
 			},
+			"validate_connection": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether Segment checks that it can connect to the Warehouse with `settings` before the Warehouse is created or its settings are updated. A failed check fails the apply and names the failing setting. Defaults to true.",
+			},
 		},
 	}
 }
 
+func (r *warehouseResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planMetadataID(ctx, req, resp, func(slug string, name string) (string, error) {
+		return findWarehouseMetadataID(r.authContext, r.client, r.catalog, slug, name)
+	})
+}
+
 func (r *warehouseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan models.WarehousePlan
 	diags := req.Plan.Get(ctx, &plan)
@@ -215,6 +236,13 @@ func (r *warehouseResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	if plan.ValidateConnection.ValueBool() {
+		resp.Diagnostics.Append(validateWarehouseConnection(r.authContext, r.client, metadataID, settings)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	name := plan.Name.ValueStringPointer()
 	if *name == "" {
 		name = nil
@@ -258,6 +286,7 @@ func (r *warehouseResource) Create(ctx context.Context, req resource.CreateReque
 
 	// This is to satisfy terraform requirements that the returned fields must match the input ones because new settings can be generated in the response
 	state.Settings = plan.Settings
+	state.ValidateConnection = plan.ValidateConnection
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, state)
@@ -305,6 +334,12 @@ func (r *warehouseResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	var state models.WarehouseState
 
+	// Imported Warehouses have no value yet
+	state.ValidateConnection = previousState.ValidateConnection
+	if state.ValidateConnection.IsNull() {
+		state.ValidateConnection = types.BoolValue(true)
+	}
+
 	warehouse := response.Data.GetWarehouse()
 	err = state.Fill(warehouse)
 	if err != nil {
@@ -359,6 +394,20 @@ func (r *warehouseResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	settingsUnchanged, diags := plan.Settings.StringSemanticEquals(ctx, state.Settings)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only new settings are checked, so that an unreachable Warehouse can still be disabled or renamed
+	if plan.ValidateConnection.ValueBool() && !settingsUnchanged {
+		resp.Diagnostics.Append(validateWarehouseConnection(r.authContext, r.client, state.Metadata.ID.ValueString(), settings)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// The default behavior of updating settings is to upsert. However, to eliminate settings that are no longer necessary, nil is assigned to fields that are no longer found in the resource.
 	existingWarehouse, body, err := r.client.WarehousesAPI.GetWarehouse(r.authContext, state.ID.ValueString()).Execute()
 	if body != nil {
@@ -411,6 +460,7 @@ func (r *warehouseResource) Update(ctx context.Context, req resource.UpdateReque
 
 	// This is to satisfy terraform requirements that the returned fields must match the input ones because new settings can be generated in the response
 	state.Settings = plan.Settings
+	state.ValidateConnection = plan.ValidateConnection
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -463,4 +513,5 @@ func (r *warehouseResource) Configure(_ context.Context, req resource.ConfigureR
 
 	r.client = config.client
 	r.authContext = config.authContext
+	r.catalog = config.catalog
 }
@@ -16,6 +16,12 @@ func TestAccWarehouseResource(t *testing.T) {
 		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 			w.Header().Set("content-type", "application/json")
 
+			if req.URL.Path == "/warehouses/validation" {
+				_, _ = w.Write([]byte(`{"data": {"status": "CONNECTED"}}`))
+
+				return
+			}
+
 			payload := `
 				{
 					"data": {
@@ -142,6 +148,7 @@ func TestAccWarehouseResource(t *testing.T) {
 					resource.TestCheckResourceAttr("segment_warehouse.test", "metadata.options.0.description", "the option description"),
 					resource.TestCheckResourceAttr("segment_warehouse.test", "metadata.options.0.label", "the option label"),
 					resource.TestCheckResourceAttr("segment_warehouse.test", "settings", "{\"myKey\":\"myValue\"}"),
+					resource.TestCheckResourceAttr("segment_warehouse.test", "validate_connection", "true"),
 				),
 			},
 			// ImportState testing
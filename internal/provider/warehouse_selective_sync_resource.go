@@ -0,0 +1,363 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/segmentio/public-api-sdk-go/api"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/docs"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
+)
+
+var (
+	_ resource.Resource                = &warehouseSelectiveSyncResource{}
+	_ resource.ResourceWithConfigure   = &warehouseSelectiveSyncResource{}
+	_ resource.ResourceWithImportState = &warehouseSelectiveSyncResource{}
+)
+
+func NewWarehouseSelectiveSyncResource() resource.Resource {
+	return &warehouseSelectiveSyncResource{}
+}
+
+type warehouseSelectiveSyncResource struct {
+	client      *api.APIClient
+	authContext context.Context
+}
+
+func (r *warehouseSelectiveSyncResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_warehouse_selective_sync"
+}
+
+func (r *warehouseSelectiveSyncResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Configures which collections and properties of a Source are synced to a Warehouse. Collections that are not in the configuration are left untouched, and are synced again when they are removed from the configuration or when this resource is destroyed. For more information, visit the [Segment docs](https://segment.com/docs/connections/storage/warehouses/warehouse-syncs/#warehouse-selective-sync).\n\n" +
+			docs.GenerateImportDocs("<warehouse_id>:<source_id>", "segment_warehouse_selective_sync"),
+		Attributes: map[string]schema.Attribute{
+			"warehouse_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The id of the Warehouse.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The id of the Source connected to the Warehouse.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"collections": selectiveSyncCollectionsSchema("The collections of the Source whose sync is managed by Terraform."),
+		},
+	}
+}
+
+// selectiveSyncCollectionsSchema returns the schema of the collections managed by a Selective Sync resource.
+func selectiveSyncCollectionsSchema(description string) schema.SetNestedAttribute {
+	return schema.SetNestedAttribute{
+		Required:    true,
+		Description: description,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					Required:    true,
+					Description: "The name of the collection, such as `identifies`.",
+				},
+				"enabled": schema.BoolAttribute{
+					Optional:    true,
+					Computed:    true,
+					Default:     booldefault.StaticBool(true),
+					Description: "Whether the collection is synced to the Warehouse. Defaults to true.",
+				},
+				"disabled_properties": schema.SetAttribute{
+					Optional:    true,
+					Computed:    true,
+					ElementType: types.StringType,
+					Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+					Description: "The properties of the collection that are not synced to the Warehouse. Every other property is synced.",
+				},
+			},
+		},
+	}
+}
+
+func (r *warehouseSelectiveSyncResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan models.WarehouseSelectiveSyncState
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, _, err := listWarehouseSelectiveSyncs(r.authContext, r.client, plan.WarehouseID.ValueString(), plan.SourceID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Selective Sync of Source (ID: %s) in Warehouse (ID: %s)", plan.SourceID.ValueString(), plan.WarehouseID.ValueString()),
+			err.Error(),
+		)
+
+		return
+	}
+
+	// Properties that are already disabled are compared against the configuration like on an update
+	var existing models.WarehouseSelectiveSyncState
+	existing.Fill(items, plan.Collections)
+
+	diags = r.updateSelectiveSync(plan.WarehouseID.ValueString(), selectiveSyncOverrides(plan.SourceID.ValueString(), plan.Collections, existing.Collections))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *warehouseSelectiveSyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var previousState models.WarehouseSelectiveSyncState
+	diags := req.State.Get(ctx, &previousState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, statusCode, err := listWarehouseSelectiveSyncs(r.authContext, r.client, previousState.WarehouseID.ValueString(), previousState.SourceID.ValueString())
+	if err != nil {
+		if statusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Selective Sync of Source (ID: %s) in Warehouse (ID: %s)", previousState.SourceID.ValueString(), previousState.WarehouseID.ValueString()),
+			err.Error(),
+		)
+
+		return
+	}
+
+	state := models.WarehouseSelectiveSyncState{
+		WarehouseID: previousState.WarehouseID,
+		SourceID:    previousState.SourceID,
+	}
+	// Imported resources have no collections yet
+	state.Fill(items, previousState.Collections)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *warehouseSelectiveSyncResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan models.WarehouseSelectiveSyncState
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state models.WarehouseSelectiveSyncState
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = r.updateSelectiveSync(plan.WarehouseID.ValueString(), selectiveSyncOverrides(plan.SourceID.ValueString(), plan.Collections, state.Collections))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *warehouseSelectiveSyncResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var config models.WarehouseSelectiveSyncState
+	diags := req.State.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Everything that was managed is synced again
+	diags = r.updateSelectiveSync(config.WarehouseID.ValueString(), selectiveSyncOverrides(config.SourceID.ValueString(), nil, config.Collections))
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *warehouseSelectiveSyncResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.Split(req.ID, ":")
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <warehouse_id>:<source_id>. Got: %q", req.ID),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("warehouse_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_id"), idParts[1])...)
+}
+
+func (r *warehouseSelectiveSyncResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected ClientInfo, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = config.client
+	r.authContext = config.authContext
+}
+
+// updateSelectiveSync applies the overrides to the Warehouse and surfaces the warnings returned by Segment.
+func (r *warehouseSelectiveSyncResource) updateSelectiveSync(warehouseID string, overrides []api.WarehouseSyncOverrideV1) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if len(overrides) == 0 {
+		return diags
+	}
+
+	out, body, err := r.client.SelectiveSyncAPI.UpdateSelectiveSyncForWarehouse(r.authContext, warehouseID).UpdateSelectiveSyncForWarehouseV1Input(api.UpdateSelectiveSyncForWarehouseV1Input{
+		SyncOverrides: overrides,
+	}).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("Unable to update Selective Sync of Warehouse (ID: %s)", warehouseID),
+			getError(err, body),
+		)
+
+		return diags
+	}
+
+	for _, warning := range out.Data.GetWarnings() {
+		diags.AddWarning(fmt.Sprintf("Selective Sync of Warehouse (ID: %s) was updated with a warning", warehouseID), warning)
+	}
+
+	return diags
+}
+
+// selectiveSyncOverrides returns the overrides that turn the previous collections into the planned ones. Collections and
+// properties that are no longer planned are synced again.
+func selectiveSyncOverrides(sourceID string, planned []models.WarehouseSelectiveSyncCollectionState, previous []models.WarehouseSelectiveSyncCollectionState) []api.WarehouseSyncOverrideV1 {
+	previousByName := map[string]models.WarehouseSelectiveSyncCollectionState{}
+	for _, collection := range previous {
+		previousByName[collection.Name.ValueString()] = collection
+	}
+
+	overrides := []api.WarehouseSyncOverrideV1{}
+	plannedNames := map[string]bool{}
+	for _, collection := range planned {
+		name := collection.Name.ValueString()
+		plannedNames[name] = true
+
+		overrides = append(overrides, api.WarehouseSyncOverrideV1{
+			SourceId:   sourceID,
+			Collection: &name,
+			Enabled:    collection.Enabled.ValueBool(),
+		})
+
+		disabled := map[string]bool{}
+		for _, property := range collection.DisabledProperties {
+			disabled[property.ValueString()] = true
+			overrides = append(overrides, selectiveSyncPropertyOverride(sourceID, name, property.ValueString(), false))
+		}
+		for _, property := range previousByName[name].DisabledProperties {
+			if !disabled[property.ValueString()] {
+				overrides = append(overrides, selectiveSyncPropertyOverride(sourceID, name, property.ValueString(), true))
+			}
+		}
+	}
+
+	for _, collection := range previous {
+		name := collection.Name.ValueString()
+		if plannedNames[name] {
+			continue
+		}
+
+		overrides = append(overrides, api.WarehouseSyncOverrideV1{
+			SourceId:   sourceID,
+			Collection: &name,
+			Enabled:    true,
+		})
+		for _, property := range collection.DisabledProperties {
+			overrides = append(overrides, selectiveSyncPropertyOverride(sourceID, name, property.ValueString(), true))
+		}
+	}
+
+	return overrides
+}
+
+func selectiveSyncPropertyOverride(sourceID string, collection string, property string, enabled bool) api.WarehouseSyncOverrideV1 {
+	return api.WarehouseSyncOverrideV1{
+		SourceId:   sourceID,
+		Collection: &collection,
+		Property:   &property,
+		Enabled:    enabled,
+	}
+}
+
+func listWarehouseSelectiveSyncs(authContext context.Context, client *api.APIClient, warehouseID string, sourceID string) ([]api.WarehouseSelectiveSyncItemV1, int, error) {
+	items := []api.WarehouseSelectiveSyncItemV1{}
+	paginationInput := *api.NewPaginationInput(MaxPageSize)
+
+	for {
+		out, body, err := client.SelectiveSyncAPI.ListSelectiveSyncsFromWarehouseAndSource(authContext, warehouseID, sourceID).Pagination(paginationInput).Execute()
+		if body != nil {
+			defer body.Body.Close()
+		}
+		if err != nil {
+			statusCode := 0
+			if body != nil {
+				statusCode = body.StatusCode
+			}
+
+			return nil, statusCode, errors.New(getError(err, body))
+		}
+
+		items = append(items, out.Data.GetItems()...)
+
+		next := out.Data.GetPagination().Next.Get()
+		if next == nil {
+			return items, 0, nil
+		}
+		paginationInput.SetCursor(*next)
+	}
+}
@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/segmentio/public-api-sdk-go/api"
+)
+
+func TestAccWarehouseSelectiveSyncResource(t *testing.T) {
+	t.Parallel()
+
+	items := []api.WarehouseSelectiveSyncItemV1{
+		{
+			SourceId:    "my-source-id",
+			WarehouseId: "my-warehouse-id",
+			Collection:  "identifies",
+			Enabled:     true,
+			Properties:  map[string]interface{}{"context_ip": true, "email": true},
+		},
+		{
+			SourceId:    "my-source-id",
+			WarehouseId: "my-warehouse-id",
+			Collection:  "order_completed",
+			Enabled:     true,
+			Properties:  map[string]interface{}{"total": true},
+		},
+	}
+
+	fakeServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("content-type", "application/json")
+
+			var payload map[string]interface{}
+			if req.URL.Path == "/warehouses/my-warehouse-id/connected-sources/my-source-id/selective-syncs" {
+				payload = map[string]interface{}{"items": items, "pagination": map[string]interface{}{"current": "MA=="}}
+			} else if req.URL.Path == "/warehouses/my-warehouse-id/selective-sync" && req.Method == http.MethodPatch {
+				var input api.UpdateSelectiveSyncForWarehouseV1Input
+				_ = json.NewDecoder(req.Body).Decode(&input)
+
+				for _, override := range input.SyncOverrides {
+					for i, item := range items {
+						if item.Collection != *override.Collection {
+							continue
+						}
+
+						// Properties are only listed once Segment has received data for them
+						if override.Property == nil {
+							items[i].Enabled = override.Enabled
+						} else if _, ok := item.Properties[*override.Property]; ok {
+							items[i].Properties[*override.Property] = override.Enabled
+						}
+					}
+				}
+				payload = map[string]interface{}{"status": "SUCCESS", "warnings": []string{}}
+			}
+
+			out, _ := json.Marshal(map[string]interface{}{"data": payload})
+			_, _ = w.Write(out)
+		}),
+	)
+	defer fakeServer.Close()
+
+	providerConfig := `
+		provider "segment" {
+			url   = "` + fakeServer.URL + `"
+			token = "abc123"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+					resource "segment_warehouse_selective_sync" "test" {
+						warehouse_id = "my-warehouse-id"
+						source_id    = "my-source-id"
+						collections = [
+							{
+								name                = "identifies"
+								disabled_properties = ["context_ip"]
+							},
+							{
+								name    = "order_completed"
+								enabled = false
+							},
+						]
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_warehouse_selective_sync.test", "collections.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("segment_warehouse_selective_sync.test", "collections.*", map[string]string{
+						"name":                  "identifies",
+						"enabled":               "true",
+						"disabled_properties.#": "1",
+						"disabled_properties.0": "context_ip",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("segment_warehouse_selective_sync.test", "collections.*", map[string]string{
+						"name":                  "order_completed",
+						"enabled":               "false",
+						"disabled_properties.#": "0",
+					}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "segment_warehouse_selective_sync.test",
+				ImportState:                          true,
+				ImportStateId:                        "my-warehouse-id:my-source-id",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "warehouse_id",
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+					resource "segment_warehouse_selective_sync" "test" {
+						warehouse_id = "my-warehouse-id"
+						source_id    = "my-source-id"
+						collections = [
+							{
+								name                = "identifies"
+								disabled_properties = ["email"]
+							},
+						]
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_warehouse_selective_sync.test", "collections.#", "1"),
+					resource.TestCheckResourceAttr("segment_warehouse_selective_sync.test", "collections.0.name", "identifies"),
+					resource.TestCheckResourceAttr("segment_warehouse_selective_sync.test", "collections.0.disabled_properties.#", "1"),
+					resource.TestCheckResourceAttr("segment_warehouse_selective_sync.test", "collections.0.disabled_properties.0", "email"),
+				),
+			},
+			// Collections and properties that Segment has not received data for yet are kept
+			{
+				Config: providerConfig + `
+					resource "segment_warehouse_selective_sync" "test" {
+						warehouse_id = "my-warehouse-id"
+						source_id    = "my-source-id"
+						collections = [
+							{
+								name                = "identifies"
+								disabled_properties = ["email", "phone"]
+							},
+							{
+								name    = "checkout_started"
+								enabled = false
+							},
+						]
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_warehouse_selective_sync.test", "collections.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("segment_warehouse_selective_sync.test", "collections.*", map[string]string{
+						"name":                  "identifies",
+						"disabled_properties.#": "2",
+						"disabled_properties.0": "email",
+						"disabled_properties.1": "phone",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("segment_warehouse_selective_sync.test", "collections.*", map[string]string{
+						"name":    "checkout_started",
+						"enabled": "false",
+					}),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
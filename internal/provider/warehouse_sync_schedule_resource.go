@@ -0,0 +1,281 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+	// Timezones are validated even where the system has no TZ database
+	_ "time/tzdata"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/segmentio/public-api-sdk-go/api"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/docs"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
+)
+
+var (
+	_ resource.Resource                = &warehouseSyncScheduleResource{}
+	_ resource.ResourceWithConfigure   = &warehouseSyncScheduleResource{}
+	_ resource.ResourceWithImportState = &warehouseSyncScheduleResource{}
+)
+
+func NewWarehouseSyncScheduleResource() resource.Resource {
+	return &warehouseSyncScheduleResource{}
+}
+
+type warehouseSyncScheduleResource struct {
+	client      *api.APIClient
+	authContext context.Context
+}
+
+func (r *warehouseSyncScheduleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_warehouse_sync_schedule"
+}
+
+func (r *warehouseSyncScheduleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Configures the advanced sync schedule of a Warehouse, which limits the hours of the day when Segment syncs data to it. The same hours apply to every day of the week, as the Public API only supports hourly slots and not per-day slots. The advanced sync schedule is disabled when this resource is destroyed. For more information, visit the [Segment docs](https://segment.com/docs/connections/storage/warehouses/warehouse-syncs/#sync-frequency).\n\n" +
+			docs.GenerateImportDocs("<warehouse_id>", "segment_warehouse_sync_schedule"),
+		Attributes: map[string]schema.Attribute{
+			"warehouse_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The id of the Warehouse.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Required:    true,
+				Description: "Whether the advanced sync schedule is used. When false, Segment syncs the Warehouse on its default schedule.",
+			},
+			"timezone": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The TZ database timezone in which `hours` are expressed, such as `America/New_York`. When not set, the timezone of the current schedule is kept.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					timezoneValidator{},
+					stringvalidator.AlsoRequires(path.MatchRoot("hours")),
+				},
+			},
+			"hours": schema.SetAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.Int64Type,
+				Description: "The hours of the day, between 0 and 23, when a sync starts. Syncs do not start during the other hours. The schedule applies to every day of the week, as Segment does not support per-day schedules. When not set, the hours of the current schedule are kept.",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueInt64sAre(int64validator.Between(0, 23)),
+					setvalidator.AlsoRequires(path.MatchRoot("timezone")),
+				},
+			},
+		},
+	}
+}
+
+func (r *warehouseSyncScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan models.WarehouseSyncScheduleState
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule, diags := plan.ToAPIValue(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	out, body, err := r.client.SelectiveSyncAPI.ReplaceAdvancedSyncScheduleForWarehouse(r.authContext, plan.WarehouseID.ValueString()).ReplaceAdvancedSyncScheduleForWarehouseV1Input(api.ReplaceAdvancedSyncScheduleForWarehouseV1Input{
+		Enabled:  plan.Enabled.ValueBool(),
+		Schedule: schedule,
+	}).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to create sync schedule of Warehouse (ID: %s)", plan.WarehouseID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+
+	state := models.WarehouseSyncScheduleState{WarehouseID: plan.WarehouseID}
+	state.Fill(out.Data.Enabled, out.Data.Schedule)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *warehouseSyncScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var previousState models.WarehouseSyncScheduleState
+	diags := req.State.Get(ctx, &previousState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	out, body, err := r.client.SelectiveSyncAPI.GetAdvancedSyncScheduleFromWarehouse(r.authContext, previousState.WarehouseID.ValueString()).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		if body != nil && body.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read sync schedule of Warehouse (ID: %s)", previousState.WarehouseID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+
+	state := models.WarehouseSyncScheduleState{WarehouseID: previousState.WarehouseID}
+	state.Fill(out.Data.Enabled, out.Data.Schedule)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *warehouseSyncScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan models.WarehouseSyncScheduleState
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule, diags := plan.ToAPIValue(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	out, body, err := r.client.SelectiveSyncAPI.ReplaceAdvancedSyncScheduleForWarehouse(r.authContext, plan.WarehouseID.ValueString()).ReplaceAdvancedSyncScheduleForWarehouseV1Input(api.ReplaceAdvancedSyncScheduleForWarehouseV1Input{
+		Enabled:  plan.Enabled.ValueBool(),
+		Schedule: schedule,
+	}).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to update sync schedule of Warehouse (ID: %s)", plan.WarehouseID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+
+	state := models.WarehouseSyncScheduleState{WarehouseID: plan.WarehouseID}
+	state.Fill(out.Data.Enabled, out.Data.Schedule)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *warehouseSyncScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var config models.WarehouseSyncScheduleState
+	diags := req.State.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, body, err := r.client.SelectiveSyncAPI.ReplaceAdvancedSyncScheduleForWarehouse(r.authContext, config.WarehouseID.ValueString()).ReplaceAdvancedSyncScheduleForWarehouseV1Input(api.ReplaceAdvancedSyncScheduleForWarehouseV1Input{
+		Enabled: false,
+	}).Execute()
+	if body != nil {
+		defer body.Body.Close()
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to disable sync schedule of Warehouse (ID: %s)", config.WarehouseID.ValueString()),
+			getError(err, body),
+		)
+
+		return
+	}
+}
+
+func (r *warehouseSyncScheduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("warehouse_id"), req, resp)
+}
+
+func (r *warehouseSyncScheduleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected ClientInfo, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = config.client
+	r.authContext = config.authContext
+}
+
+// timezoneValidator checks that a string is a timezone of the TZ database.
+type timezoneValidator struct{}
+
+func (v timezoneValidator) Description(_ context.Context) string {
+	return "value must be a TZ database timezone, such as America/New_York"
+}
+
+func (v timezoneValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timezoneValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.LoadLocation(req.ConfigValue.ValueString()); err != nil || req.ConfigValue.ValueString() == "" || req.ConfigValue.ValueString() == "Local" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid timezone",
+			fmt.Sprintf("%q is not a TZ database timezone, such as America/New_York.", req.ConfigValue.ValueString()),
+		)
+	}
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/segmentio/public-api-sdk-go/api"
+)
+
+func TestAccWarehouseSyncScheduleResource(t *testing.T) {
+	t.Parallel()
+
+	schedule := api.GetAdvancedSyncScheduleFromWarehouseV1Output{Enabled: false}
+
+	fakeServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("content-type", "application/json")
+
+			if req.URL.Path == "/warehouses/my-warehouse-id/advanced-sync-schedule" && req.Method == http.MethodPut {
+				var input api.ReplaceAdvancedSyncScheduleForWarehouseV1Input
+				_ = json.NewDecoder(req.Body).Decode(&input)
+
+				// The previous schedule is kept when none is sent
+				schedule.Enabled = input.Enabled
+				if input.Schedule != nil {
+					schedule.Schedule = &api.AdvancedWarehouseSyncScheduleV1Output{
+						Timezone: &input.Schedule.Timezone,
+						Times:    input.Schedule.Times,
+					}
+				}
+			}
+
+			out, _ := json.Marshal(map[string]interface{}{"data": schedule})
+			_, _ = w.Write(out)
+		}),
+	)
+	defer fakeServer.Close()
+
+	providerConfig := `
+		provider "segment" {
+			url   = "` + fakeServer.URL + `"
+			token = "abc123"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config: providerConfig + `
+					resource "segment_warehouse_sync_schedule" "test" {
+						warehouse_id = "my-warehouse-id"
+						enabled      = true
+						timezone     = "Mars/Olympus_Mons"
+						hours        = [24]
+					}
+				`,
+				ExpectError: regexp.MustCompile(`(?s)Invalid timezone.*value must be between 0 and 23`),
+			},
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+					resource "segment_warehouse_sync_schedule" "test" {
+						warehouse_id = "my-warehouse-id"
+						enabled      = true
+						timezone     = "America/New_York"
+						hours        = [2, 14]
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_warehouse_sync_schedule.test", "warehouse_id", "my-warehouse-id"),
+					resource.TestCheckResourceAttr("segment_warehouse_sync_schedule.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("segment_warehouse_sync_schedule.test", "timezone", "America/New_York"),
+					resource.TestCheckResourceAttr("segment_warehouse_sync_schedule.test", "hours.#", "2"),
+					resource.TestCheckTypeSetElemAttr("segment_warehouse_sync_schedule.test", "hours.*", "2"),
+					resource.TestCheckTypeSetElemAttr("segment_warehouse_sync_schedule.test", "hours.*", "14"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "segment_warehouse_sync_schedule.test",
+				ImportState:                          true,
+				ImportStateId:                        "my-warehouse-id",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "warehouse_id",
+			},
+			// Update and Read testing, where the current schedule is kept as it is not configured
+			{
+				Config: providerConfig + `
+					resource "segment_warehouse_sync_schedule" "test" {
+						warehouse_id = "my-warehouse-id"
+						enabled      = false
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("segment_warehouse_sync_schedule.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("segment_warehouse_sync_schedule.test", "timezone", "America/New_York"),
+					resource.TestCheckResourceAttr("segment_warehouse_sync_schedule.test", "hours.#", "2"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
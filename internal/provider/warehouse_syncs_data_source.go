@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/segmentio/public-api-sdk-go/api"
+	"github.com/segmentio/terraform-provider-segment/internal/provider/models"
+)
+
+// DefaultWarehouseSyncsLimit is the number of sync reports returned when `limit` is not set.
+const DefaultWarehouseSyncsLimit = 100
+
+var (
+	_ datasource.DataSource              = &warehouseSyncsDataSource{}
+	_ datasource.DataSourceWithConfigure = &warehouseSyncsDataSource{}
+)
+
+func NewWarehouseSyncsDataSource() datasource.DataSource {
+	return &warehouseSyncsDataSource{}
+}
+
+type warehouseSyncsDataSource struct {
+	client      *api.APIClient
+	authContext context.Context
+}
+
+func (d *warehouseSyncsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_warehouse_syncs"
+}
+
+func (d *warehouseSyncsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the latest sync reports of a Warehouse, such as to check when it last synced successfully. For more information, visit the [Segment docs](https://segment.com/docs/connections/storage/warehouses/warehouse-syncs/).",
+		Attributes: map[string]schema.Attribute{
+			"warehouse_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The id of the Warehouse.",
+			},
+			"source_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The id of a Source connected to the Warehouse. When set, only the syncs of this Source are returned.",
+			},
+			"limit": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The maximum number of sync reports to return. Defaults to %d.", DefaultWarehouseSyncsLimit),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"reports": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The sync reports of the Warehouse.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"source_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The id of the Source loaded in the sync.",
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "The status of the sync.",
+						},
+						"start": schema.StringAttribute{
+							Computed:    true,
+							Description: "The start time of the sync.",
+						},
+						"end": schema.StringAttribute{
+							Computed:    true,
+							Description: "The time the sync completed. Null if the sync has not finished yet.",
+						},
+						"duration": schema.Float64Attribute{
+							Computed:    true,
+							Description: "The duration of the sync in seconds. The partial duration if the sync has not finished yet.",
+						},
+						"human_duration": schema.StringAttribute{
+							Computed:    true,
+							Description: "The human-readable counterpart of `duration`.",
+						},
+						"rows_synced": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The number of rows synced into the Warehouse.",
+						},
+						"notices": schema.ListNestedAttribute{
+							Computed:    true,
+							Description: "The events that occurred during the sync, such as errors.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"level": schema.StringAttribute{
+										Computed:    true,
+										Description: "The severity of the notice.",
+									},
+									"message": schema.StringAttribute{
+										Computed:    true,
+										Description: "The human-readable message that describes the notice.",
+									},
+									"created_at": schema.StringAttribute{
+										Computed:    true,
+										Description: "The time the notice was created.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *warehouseSyncsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state models.WarehouseSyncsState
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := int64(DefaultWarehouseSyncsLimit)
+	if !state.Limit.IsNull() {
+		limit = state.Limit.ValueInt64()
+	}
+
+	reports, err := listWarehouseSyncs(d.authContext, d.client, state.WarehouseID.ValueString(), state.SourceID.ValueString(), int(limit))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read syncs of Warehouse (ID: %s)", state.WarehouseID.ValueString()),
+			err.Error(),
+		)
+
+		return
+	}
+
+	state.Fill(reports)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (d *warehouseSyncsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientInfo, ok := req.ProviderData.(*ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ClientInfo, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = clientInfo.client
+	d.authContext = clientInfo.authContext
+}
+
+// listWarehouseSyncs returns up to `limit` of the latest syncs of a Warehouse, only for the given Source when it is not empty.
+func listWarehouseSyncs(authContext context.Context, client *api.APIClient, warehouseID string, sourceID string, limit int) ([]api.SyncV1, error) {
+	reports := []api.SyncV1{}
+
+	var pageToken *string
+	firstPageToken := "MA=="
+	pageToken = &firstPageToken
+
+	for pageToken != nil && len(reports) < limit {
+		pagination := api.PaginationInput{Count: int32(min(MaxPageSize, limit-len(reports))), Cursor: pageToken}
+
+		var page []api.SyncV1
+		var paginationOutput api.PaginationOutput
+		if sourceID == "" {
+			out, body, err := client.SelectiveSyncAPI.ListSyncsFromWarehouse(authContext, warehouseID).Pagination(pagination).Execute()
+			if body != nil {
+				defer body.Body.Close()
+			}
+			if err != nil {
+				return nil, errors.New(getError(err, body))
+			}
+			page = out.Data.Reports
+			paginationOutput = out.Data.Pagination
+		} else {
+			out, body, err := client.SelectiveSyncAPI.ListSyncsFromWarehouseAndSource(authContext, warehouseID, sourceID).Pagination(pagination).Execute()
+			if body != nil {
+				defer body.Body.Close()
+			}
+			if err != nil {
+				return nil, errors.New(getError(err, body))
+			}
+			page = out.Data.Reports
+			paginationOutput = out.Data.Pagination
+		}
+
+		reports = append(reports, page...)
+		pageToken = paginationOutput.Next.Get()
+	}
+
+	if len(reports) > limit {
+		reports = reports[:limit]
+	}
+
+	return reports, nil
+}
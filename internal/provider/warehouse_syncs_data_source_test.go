@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccWarehouseSyncsDataSource(t *testing.T) {
+	t.Parallel()
+
+	fakeServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("content-type", "application/json")
+
+			payload := ""
+			switch {
+			case req.URL.Path == "/warehouses/my-warehouse-id/syncs" && req.URL.Query().Get("pagination[cursor]") == "MA==":
+				payload = `
+					{
+						"data": {
+							"reports": [
+								{
+									"sourceId": "my-source-id",
+									"start": "2024-01-02T00:00:00Z",
+									"end": null,
+									"status": "PENDING",
+									"duration": 12.5,
+									"humanDuration": "12 seconds",
+									"count": 0,
+									"notices": []
+								}
+							],
+							"pagination": {
+								"current": "MA==",
+								"next": "MQ=="
+							}
+						}
+					}
+				`
+			case req.URL.Path == "/warehouses/my-warehouse-id/syncs":
+				payload = `
+					{
+						"data": {
+							"reports": [
+								{
+									"sourceId": "my-other-source-id",
+									"start": "2024-01-01T00:00:00Z",
+									"end": "2024-01-01T00:10:00Z",
+									"status": "FAILED",
+									"duration": 600,
+									"humanDuration": "10 minutes",
+									"count": 1500,
+									"notices": [
+										{
+											"level": "error",
+											"message": "Permission denied for schema my_source",
+											"createdAt": "2024-01-01T00:10:00Z"
+										}
+									]
+								}
+							],
+							"pagination": {
+								"current": "MQ=="
+							}
+						}
+					}
+				`
+			case req.URL.Path == "/warehouses/my-warehouse-id/connected-sources/my-source-id/syncs":
+				payload = `
+					{
+						"data": {
+							"reports": [
+								{
+									"sourceId": "my-source-id",
+									"start": "2024-01-02T00:00:00Z",
+									"end": "2024-01-02T00:01:00Z",
+									"status": "SUCCESS",
+									"duration": 60,
+									"humanDuration": "1 minute",
+									"count": 42,
+									"notices": []
+								}
+							],
+							"pagination": {
+								"current": "MA=="
+							}
+						}
+					}
+				`
+			}
+
+			_, _ = w.Write([]byte(payload))
+		}),
+	)
+	defer fakeServer.Close()
+
+	providerConfig := `
+		provider "segment" {
+			url   = "` + fakeServer.URL + `"
+			token = "abc123"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+					data "segment_warehouse_syncs" "all" {
+						warehouse_id = "my-warehouse-id"
+					}
+
+					data "segment_warehouse_syncs" "source" {
+						warehouse_id = "my-warehouse-id"
+						source_id    = "my-source-id"
+					}
+
+					data "segment_warehouse_syncs" "latest" {
+						warehouse_id = "my-warehouse-id"
+						limit        = 1
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.segment_warehouse_syncs.all", "reports.#", "2"),
+					resource.TestCheckResourceAttr("data.segment_warehouse_syncs.all", "reports.0.status", "PENDING"),
+					resource.TestCheckNoResourceAttr("data.segment_warehouse_syncs.all", "reports.0.end"),
+					resource.TestCheckResourceAttr("data.segment_warehouse_syncs.all", "reports.0.duration", "12.5"),
+					resource.TestCheckResourceAttr("data.segment_warehouse_syncs.all", "reports.1.source_id", "my-other-source-id"),
+					resource.TestCheckResourceAttr("data.segment_warehouse_syncs.all", "reports.1.status", "FAILED"),
+					resource.TestCheckResourceAttr("data.segment_warehouse_syncs.all", "reports.1.end", "2024-01-01T00:10:00Z"),
+					resource.TestCheckResourceAttr("data.segment_warehouse_syncs.all", "reports.1.rows_synced", "1500"),
+					resource.TestCheckResourceAttr("data.segment_warehouse_syncs.all", "reports.1.notices.#", "1"),
+					resource.TestCheckResourceAttr("data.segment_warehouse_syncs.all", "reports.1.notices.0.level", "error"),
+					resource.TestCheckResourceAttr("data.segment_warehouse_syncs.all", "reports.1.notices.0.message", "Permission denied for schema my_source"),
+					resource.TestCheckResourceAttr("data.segment_warehouse_syncs.source", "reports.#", "1"),
+					resource.TestCheckResourceAttr("data.segment_warehouse_syncs.source", "reports.0.status", "SUCCESS"),
+					resource.TestCheckResourceAttr("data.segment_warehouse_syncs.source", "reports.0.rows_synced", "42"),
+					resource.TestCheckResourceAttr("data.segment_warehouse_syncs.latest", "reports.#", "1"),
+					resource.TestCheckResourceAttr("data.segment_warehouse_syncs.latest", "reports.0.status", "PENDING"),
+				),
+			},
+		},
+	})
+}